@@ -0,0 +1,425 @@
+// Package analyzer provides the analysis.Analyzer reporting the validate tags, which the generator rejects,
+// and the files generated by the validator, which are out of date.
+package analyzer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/paluszkiewiczB/validator/internal"
+)
+
+// Analyzer reports:
+//   - invalid syntax of the validate tags and the +validate markers,
+//   - unknown rules,
+//   - rules with the wrong number of parameters or not supported by the type of the field,
+//   - rules of the markers conflicting with the rules of the tag,
+//   - eqfield and gte referring to the fields, which do not exist,
+//   - invalid mod tags, unknown modifiers and modifiers not supported by the type of the field,
+//   - files generated by the validator in the go:generate directives of the package, which are out of date.
+//
+// Diagnostics of the tags and the markers point at the offending rule, once per field. The tag keys and aliases of the rules are read from the config
+// file of the package, like the generator does, the -tag-keys flag takes precedence over the config file.
+var Analyzer = &analysis.Analyzer{
+	Name: "validatetags",
+	Doc:  "reports invalid validate tags and stale code generated by the validator",
+	URL:  "https://github.com/paluszkiewiczB/validator",
+	Run:  run,
+}
+
+var tagKeys string
+
+func init() {
+	Analyzer.Flags.StringVar(&tagKeys, "tag-keys", "", "comma separated struct tag keys of the rules in the priority order, the config file or validate by default")
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	aliases, err := internal.PackageAliases(pass.Fset, pass.Files...)
+	if err != nil {
+		pass.Reportf(pass.Files[0].Package, "%v", err)
+	}
+
+	configs := map[string]internal.Config{}
+	for _, file := range pass.Files {
+		if ast.IsGenerated(file) {
+			continue
+		}
+
+		dir := filepath.Dir(pass.Fset.Position(file.Pos()).Filename)
+		cfg, ok := configs[dir]
+		if !ok {
+			if cfg, _, err = internal.FindConfig(dir); err != nil {
+				pass.Reportf(file.Package, "%v", err)
+				continue
+			}
+
+			cfg = cfg.WithAliases(aliases)
+			configs[dir] = cfg
+		}
+
+		syntax := internal.Syntax{TagKeys: cfg.TagKeys, Aliases: cfg.Aliases}
+		if tagKeys != "" {
+			syntax.TagKeys = strings.Split(tagKeys, ",")
+		}
+
+		markers := typeMarkers(pass, file, syntax)
+		ast.Inspect(file, func(n ast.Node) bool {
+			spec, ok := n.(*ast.TypeSpec)
+			if !ok {
+				return true
+			}
+
+			if s, ok := spec.Type.(*ast.StructType); ok {
+				checkStruct(pass, internal.Struct{Name: spec.Name.Name, Ast: s, TypeParams: internal.TypeParams(spec, file)}, syntax, markers)
+			}
+
+			return true
+		})
+
+		for _, group := range file.Comments {
+			for _, c := range group.List {
+				if d, ok := parseDirective(c.Text); ok {
+					checkGenerated(pass, file, c.Pos(), cfg, d)
+				}
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// typeMarkers returns the markers of the named types declared in the file by the name of the type,
+// reporting the invalid ones.
+func typeMarkers(pass *analysis.Pass, file *ast.File, syntax internal.Syntax) map[string]internal.Groups {
+	out := map[string]internal.Groups{}
+	for _, decl := range file.Decls {
+		d, ok := decl.(*ast.GenDecl)
+		if !ok || d.Tok != token.TYPE {
+			continue
+		}
+
+		for _, spec := range d.Specs {
+			t := spec.(*ast.TypeSpec)
+			doc := t.Doc
+			if doc == nil && len(d.Specs) == 1 {
+				doc = d.Doc
+			}
+
+			groups, err := internal.ParseMarkersOf(pass.Fset, syntax, doc)
+			if err != nil {
+				reportError(pass, t.Pos(), "invalid "+internal.MarkerPrefix+" marker", err)
+				continue
+			}
+
+			if len(groups) != 0 {
+				out[t.Name.Name] = groups
+			}
+		}
+	}
+
+	return out
+}
+
+// checkStruct reports the rules of the tags and the markers of the fields of the struct, which the generator rejects.
+// Fields declared with several names share the tag and the markers, so they are reported once, by the first name.
+func checkStruct(pass *analysis.Pass, str internal.Struct, syntax internal.Syntax, markers map[string]internal.Groups) {
+	fields := map[string]bool{}
+	for _, f := range str.Ast.Fields.List {
+		for _, ident := range f.Names {
+			fields[ident.Name] = true
+		}
+	}
+
+	for _, f := range str.Ast.Fields.List {
+		if len(f.Names) == 0 {
+			continue
+		}
+
+		tag, at := "``", f.Pos()
+		if f.Tag != nil {
+			tag, at = f.Tag.Value, f.Tag.Pos()
+		}
+
+		groups, err := internal.ParseGroupsOf(tag, pass.Fset.Position(at), syntax)
+		if err != nil {
+			reportError(pass, at, "invalid validate tag", err)
+			continue
+		}
+
+		fieldMarkers, err := internal.ParseMarkersOf(pass.Fset, syntax, f.Doc, f.Comment)
+		if err != nil {
+			reportError(pass, at, "invalid "+internal.MarkerPrefix+" marker", err)
+			continue
+		}
+
+		for group, vals := range markers[string(internal.Type(types.ExprString(f.Type)).Deref())] {
+			fieldMarkers[group] = append(slices.Clone(vals), fieldMarkers[group]...)
+		}
+
+		if groups, err = internal.MergeMarkers(fieldMarkers, groups); err != nil {
+			reportError(pass, at, "invalid "+internal.MarkerPrefix+" marker", err)
+			continue
+		}
+
+		field := internal.Field{Name: f.Names[0].Name, Type: internal.Type(types.ExprString(f.Type)), Ast: f}
+		if f.Tag != nil {
+			checkModifiers(pass, str, field)
+		}
+
+		for _, vals := range groups {
+			// the rules after dive are checked with the type of the elements
+			vals, elems, dive := vals.Dive()
+			checkRules(pass, at, str, field, vals, fields)
+			if dive && field.Type.IsSlice() {
+				checkRules(pass, at, str, field.Elem(elems), elems, fields)
+			}
+		}
+	}
+}
+
+// checkRules reports the rules of the field, which the generator rejects, at is the position of the tag of the field.
+func checkRules(pass *analysis.Pass, at token.Pos, str internal.Struct, field internal.Field, vals internal.Validations, fields map[string]bool) {
+	for _, alts := range vals {
+		for _, rule := range alts {
+			if msg := checkRule(rule, str, field, fields); msg != "" {
+				pass.Reportf(tokenPos(pass, at, rule.Pos), "%s", msg)
+			}
+		}
+	}
+}
+
+// checkModifiers reports the invalid mod tag of the field and its modifiers, which the generator rejects.
+func checkModifiers(pass *analysis.Pass, str internal.Struct, field internal.Field) {
+	at := field.Ast.Tag.Pos()
+	mods, err := internal.ParseModifiers(field.Ast.Tag.Value, pass.Fset.Position(at))
+	if err != nil {
+		reportError(pass, at, "invalid "+internal.ModTagKey+" tag", err)
+		return
+	}
+
+	for _, mod := range mods {
+		if msg := checkModifier(mod, str, field); msg != "" {
+			pass.Reportf(tokenPos(pass, at, mod.Pos), "%s", msg)
+		}
+	}
+}
+
+// checkModifier returns the problem of the modifier of the field, or empty string when the generator accepts it.
+func checkModifier(mod internal.Rule, str internal.Struct, field internal.Field) string {
+	fun := internal.ModifierFor(mod.Name)
+	if fun == nil {
+		return fmt.Sprintf("unknown modifier %q of field %q", mod.Name, field.Name)
+	}
+
+	if _, err := fun(mod, str, field, internal.FieldAccess(str, field)); err != nil {
+		return fmt.Sprintf("modifier %q of field %q: %v", mod.Name, field.Name, err)
+	}
+
+	return ""
+}
+
+// reportError reports the error of parsing the tag or the marker at the offending rune, at is the position
+// in the same file reported, when the error has no position.
+func reportError(pass *analysis.Pass, at token.Pos, prefix string, err error) {
+	var tagErr *internal.TagError
+	if errors.As(err, &tagErr) {
+		pass.Reportf(tokenPos(pass, at, tagErr.Pos), "%s: %s", prefix, tagErr.Msg)
+		return
+	}
+
+	pass.Reportf(at, "%s: %v", prefix, err)
+}
+
+// checkRule returns the problem of the rule of the field, or empty string when the generator accepts it.
+func checkRule(rule internal.Rule, str internal.Struct, field internal.Field, fields map[string]bool) string {
+	gen := internal.GeneratorFor(rule.Name)
+	if gen == nil {
+		return fmt.Sprintf("unknown rule %q of field %q", rule.Name, field.Name)
+	}
+
+	if _, err := gen.Generate(rule, str, field); err != nil {
+		return fmt.Sprintf("rule %q of field %q: %v", rule.Name, field.Name, err)
+	}
+
+	if (rule.Name == internal.Eqfield || rule.Name == internal.Gte) && !fields[rule.Param] {
+		return fmt.Sprintf("rule %q of field %q refers to field %q, which does not exist in struct %q", rule.Name, field.Name, rule.Param, str.Name)
+	}
+
+	return ""
+}
+
+// tokenPos returns the position of the rune of the tag or the marker, at is the position in the same file.
+func tokenPos(pass *analysis.Pass, at token.Pos, pos token.Position) token.Pos {
+	f := pass.Fset.File(at)
+	if f == nil || pos.Offset < 0 || pos.Offset > f.Size() {
+		return at
+	}
+
+	return f.Pos(pos.Offset)
+}
+
+// directive are the flags of the go:generate directive running the validator.
+// The flags, which are not set, are empty, except the in, and the settings of the config file are used instead.
+type directive struct {
+	in, out, pkg, nameTag, messages, tagKeys, errors, receiver, unexported string
+	// set are the names of the flags set in the directive
+	set map[string]bool
+}
+
+// modulePath is the import path of the validator.
+const modulePath = "github.com/paluszkiewiczB/validator"
+
+// parseDirective returns the flags of the go:generate directive running the validator.
+// The validator is either run by its import path, optionally with the version, or with 'go run .' in its own module,
+// which must set the flag in or out, so the directives of the other generators run with 'go run .' are skipped.
+func parseDirective(text string) (directive, bool) {
+	args, ok := strings.CutPrefix(text, "//go:generate ")
+	if !ok {
+		return directive{}, false
+	}
+
+	fields := strings.Fields(args)
+	d := directive{in: "main.go", set: map[string]bool{}}
+	byPath, runDot := false, false
+	for i := 0; i < len(fields); i++ {
+		arg := fields[i]
+		if arg == modulePath || strings.HasPrefix(arg, modulePath+"@") {
+			byPath = true
+			continue
+		}
+
+		if arg == "." && i == 2 && fields[0] == "go" && fields[1] == "run" {
+			runDot = true
+			continue
+		}
+
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		target := map[string]*string{"in": &d.in, "out": &d.out, "outpkg": &d.pkg, "name-tag": &d.nameTag, "messages": &d.messages, "tag-keys": &d.tagKeys, "errors": &d.errors, "receiver": &d.receiver, "unexported": &d.unexported}[name]
+		if !strings.HasPrefix(arg, "-") || target == nil {
+			continue
+		}
+
+		if !hasValue && name == "unexported" {
+			// the boolean flag does not take the next argument
+			value = "true"
+		} else if !hasValue && i+1 < len(fields) {
+			i++
+			value = fields[i]
+		}
+
+		*target = value
+		d.set[name] = true
+	}
+
+	return d, byPath || (runDot && (d.set["in"] || d.set["out"]))
+}
+
+// checkGenerated reports the directive, when the file generated by it differs from the output of the generator.
+func checkGenerated(pass *analysis.Pass, file *ast.File, at token.Pos, cfg internal.Config, d directive) {
+	dir := filepath.Dir(pass.Fset.Position(file.Pos()).Filename)
+	var src *ast.File
+	for _, f := range pass.Files {
+		if pass.Fset.Position(f.Pos()).Filename == filepath.Join(dir, d.in) {
+			src = f
+		}
+	}
+
+	if src == nil {
+		// the input belongs to the other variant of the package, e.g. the test one
+		return
+	}
+
+	if !d.set["out"] {
+		d.out = cfg.OutFile(d.in)
+	}
+
+	expected, err := generate(pass.Fset, src, pass.Files, dir, cfg, d)
+	if err != nil {
+		pass.Reportf(at, "generating validations of %q: %v", d.in, err)
+		return
+	}
+
+	actual, err := os.ReadFile(filepath.Join(dir, d.out))
+	if errors.Is(err, fs.ErrNotExist) {
+		pass.Reportf(at, "generated file %q does not exist, run go generate", d.out)
+		return
+	}
+
+	if err != nil {
+		pass.Reportf(at, "reading generated file %q: %v", d.out, err)
+		return
+	}
+
+	if !bytes.Equal(expected, actual) {
+		pass.Reportf(at, "generated file %q is out of date, run go generate", d.out)
+	}
+}
+
+// generate returns the output of the generator run with the flags of the directive,
+// files are the files of the package, which declare the methods of the structs.
+// The settings are passed to the generator explicitly, analyzers of the packages run in parallel.
+func generate(fset *token.FileSet, src *ast.File, files []*ast.File, dir string, cfg internal.Config, d directive) ([]byte, error) {
+	settings := cfg.Settings()
+	pkg := cfg.OutPkg
+	if d.set["outpkg"] {
+		pkg = d.pkg
+	}
+
+	if d.set["name-tag"] {
+		settings.NameTag = d.nameTag
+	}
+
+	if d.set["tag-keys"] {
+		settings.TagKeys = strings.Split(d.tagKeys, ",")
+	}
+
+	if d.set["errors"] {
+		settings.Errors = internal.ErrorStyle(d.errors)
+	}
+
+	if d.set["receiver"] {
+		receiver, err := internal.ParseReceiverKind(d.receiver)
+		if err != nil {
+			return nil, err
+		}
+
+		settings.Receivers = receiver
+	}
+
+	if d.set["unexported"] {
+		unexported, err := strconv.ParseBool(d.unexported)
+		if err != nil {
+			return nil, fmt.Errorf("parsing flag unexported: %w", err)
+		}
+
+		settings.Unexported = unexported
+	}
+
+	if d.messages != "" {
+		msgs, err := internal.ReadMessages(filepath.Join(dir, d.messages))
+		if err != nil {
+			return nil, err
+		}
+
+		settings.Templates = settings.Templates.With(msgs)
+	}
+
+	structs, err := internal.FindStructsWith(fset, src, settings)
+	if err != nil {
+		return nil, err
+	}
+
+	return internal.GenerateFile(internal.DetectReceivers(structs, files...), pkg)
+}
@@ -0,0 +1,9 @@
+package fresh
+
+//go:generate go run github.com/paluszkiewiczB/validator -in=fresh.go -out=generated.go -outpkg=fresh -name-tag=json
+
+type User struct {
+	Name     string `json:"name" validate:"required,min=3"`
+	Password string `validate:"required"`
+	Repeated string `validate:"eqfield=Password"`
+}
@@ -0,0 +1,6 @@
+package fresh
+
+// Rename has the pointer receiver, so the generated Validate has the pointer receiver too.
+func (u *User) Rename(name string) {
+	u.Name = name
+}
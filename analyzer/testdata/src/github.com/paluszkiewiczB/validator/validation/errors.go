@@ -0,0 +1,11 @@
+// Package validation is the stub of the package used by the generated code.
+package validation
+
+type FieldError struct {
+	Struct, Field, StructField, Tag, ActualTag, Param, Message string
+	Value                                                      any
+}
+
+func (e *FieldError) Error() string {
+	return e.Message
+}
@@ -0,0 +1,25 @@
+// Code generated by validator. DO NOT EDIT.
+
+package stale
+
+import (
+	"github.com/paluszkiewiczB/validator/validation"
+	"unicode/utf8"
+)
+
+// Validate implements Validator.
+func (u User) Validate() error {
+	if len(u.Name) == 0 {
+		return &validation.FieldError{Struct: "User", Field: "name", StructField: "Name", Tag: "required", Param: "", Value: u.Name, Message: "field \"name\" is required"}
+	}
+	if utf8.RuneCountInString(u.Name) < 3 {
+		return &validation.FieldError{Struct: "User", Field: "name", StructField: "Name", Tag: "min", Param: "3", Value: u.Name, Message: "field \"name\" must be at least 3"}
+	}
+	if len(u.Password) == 0 {
+		return &validation.FieldError{Struct: "User", Field: "Password", StructField: "Password", Tag: "required", Param: "", Value: u.Password, Message: "field \"Password\" is required"}
+	}
+	if u.Repeated != u.Password {
+		return &validation.FieldError{Struct: "User", Field: "Repeated", StructField: "Repeated", Tag: "eqfield", Param: "Password", Value: u.Repeated, Message: "field \"Repeated\" must be equal to \"Password\""}
+	}
+	return nil
+}
@@ -0,0 +1,9 @@
+package stale
+
+//go:generate go run github.com/paluszkiewiczB/validator -in=stale.go -out=generated.go -outpkg=stale -name-tag=json // want `generated file "generated.go" is out of date, run go generate`
+
+type User struct {
+	Name     string `json:"name" validate:"required,min=3"`
+	Password string `validate:"required,max=64"`
+	Repeated string `validate:"eqfield=Password"`
+}
@@ -0,0 +1,51 @@
+package tags
+
+type User struct {
+	Name     string            `validate:"required,unknown"` // want `unknown rule "unknown" of field "Name"`
+	Password string            `validate:"eqfield"`          // want `rule "eqfield" of field "Password": validation "eqfield" expects exactly 1 option, but got: 0 - ""`
+	Repeated string            `validate:"eqfield=Pasword"`  // want `rule "eqfield" of field "Repeated" refers to field "Pasword", which does not exist in struct "User"`
+	Age      int               `validate:"required"`         // want `rule "required" of field "Age": unsupported type for validation: "required"`
+	Limit    int               `validate:"gte=Age"`
+	Email    *string           `validate.create:"required,min=3"` // want `rule "min" of field "Email": unsupported type for validation: "min", type: "\*string"`
+	Labels   map[string]string `validate:"required,,"`            // want `invalid validate tag: expected rule before ','`
+	Tags     []string          `json:"tags" validate:"max=5"`
+}
+
+type Range[T comparable, N ~int | ~float64] struct {
+	Min   N `validate:"min=0"`
+	Max   N `validate:"gte=Min"`
+	Value T `validate:"required"`
+	Other T `validate:"gte=Value"` // want `rule "gte" of field "Other": unsupported type for validation: "gte", type parameter T comparable is not ordered`
+	Limit N `validate:"gte=Value"` // want `rule "gte" of field "Limit": validation "gte" expects field "Value" of type N, got: T`
+}
+
+type Search struct {
+	Query string  `mod:"trim,lcase" validate:"required"`
+	Email *string `mod:"trim,default=a"`
+	Limit uint8   `mod:"default=300"` // want `modifier "default" of field "Limit": modifier "default" value 300 overflows type "uint8"`
+	Size  int     `mod:"trim"`        // want `modifier "trim" of field "Size": unsupported type for modifier: "trim", type: "int"`
+	Page  int     `mod:"default=one"` // want `modifier "default" of field "Page": modifier "default" expects integer, got: "one"`
+	Sort  string  `mod:"title"`       // want `unknown modifier "title" of field "Sort"`
+	Order string  `mod:"trim|lcase"`  // want `invalid mod tag: modifiers can not be alternatives, got: "trim\|lcase"`
+}
+
+// the directive runs the other command of the module, it is not checked
+//go:generate go run github.com/paluszkiewiczB/validator/cmd/validatetags -in=tags.go
+
+type Markers struct {
+	// want +1 `unknown rule "unknown" of field "Login"`
+	// +validate:unknown
+	Login string
+	// want +1 `invalid \+validate: marker: expected rule before ','`
+	// +validate:required,,min=3
+	Email string
+	// want +1 `invalid \+validate: marker: rule "min=3" of the marker conflicts with rule "min=5" of the tag`
+	// +validate:min=3
+	Nick        string `validate:"min=5"`
+	First, Last string `validate:"unknown"` // want `unknown rule "unknown" of field "First"`
+	Code        Code
+}
+
+// want +1 `invalid \+validate: marker: expected rule after ','`
+// +validate:required,
+type Code string
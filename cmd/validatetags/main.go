@@ -0,0 +1,14 @@
+// Command validatetags runs the analyzer of the validate tags, see analyzer.Analyzer.
+//
+//	go run github.com/paluszkiewiczB/validator/cmd/validatetags ./...
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/paluszkiewiczB/validator/analyzer"
+)
+
+func main() {
+	singlechecker.Main(analyzer.Analyzer)
+}
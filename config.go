@@ -0,0 +1,44 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/paluszkiewiczB/validator/internal"
+)
+
+// runConfig runs the subcommand 'config print', which prints the effective settings of the directory:
+// the defaults merged with the nearest config file and its overrides of the directory, see internal.FindConfig.
+func runConfig(args []string) error {
+	flags := flag.NewFlagSet("config", flag.ExitOnError)
+	dir := flags.String("dir", ".", "directory of the package")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "usage: validator config print [-dir directory]\n")
+		flags.PrintDefaults()
+	}
+
+	if len(args) == 0 || args[0] != "print" {
+		flags.Usage()
+		os.Exit(2)
+	}
+	Must(flags.Parse(args[1:]))
+
+	cfg, file, err := internal.FindConfig(*dir)
+	if err != nil {
+		return err
+	}
+
+	content, err := cfg.Print()
+	if err != nil {
+		return err
+	}
+
+	source := "# " + internal.ConfigFile + " not found, defaults\n"
+	if file != "" {
+		source = "# " + file + "\n"
+	}
+
+	_, err = os.Stdout.Write(append([]byte(source), content...))
+	return err
+}
@@ -0,0 +1,136 @@
+// Code generated by validator. DO NOT EDIT.
+
+package conformance_test
+
+// Case00 validates "required" on string.
+type Case00 struct {
+	V     string `validate:"required"`
+	Other string
+}
+
+// Case01 validates "required" on *string.
+type Case01 struct {
+	V     *string `validate:"required"`
+	Other *string
+}
+
+// Case02 validates "required" on []string.
+type Case02 struct {
+	V     []string `validate:"required"`
+	Other []string
+}
+
+// Case03 validates "required" on map[string]int.
+type Case03 struct {
+	V     map[string]int `validate:"required"`
+	Other map[string]int
+}
+
+// Case06 validates "min=2" on string.
+type Case06 struct {
+	V     string `validate:"min=2"`
+	Other string
+}
+
+// Case07 validates "min=2" on int.
+type Case07 struct {
+	V     int `validate:"min=2"`
+	Other int
+}
+
+// Case08 validates "min=0.5" on float64.
+type Case08 struct {
+	V     float64 `validate:"min=0.5"`
+	Other float64
+}
+
+// Case09 validates "min=1" on []string.
+type Case09 struct {
+	V     []string `validate:"min=1"`
+	Other []string
+}
+
+// Case10 validates "max=2" on string.
+type Case10 struct {
+	V     string `validate:"max=2"`
+	Other string
+}
+
+// Case11 validates "max=10" on uint8.
+type Case11 struct {
+	V     uint8 `validate:"max=10"`
+	Other uint8
+}
+
+// Case12 validates "max=1" on map[string]int.
+type Case12 struct {
+	V     map[string]int `validate:"max=1"`
+	Other map[string]int
+}
+
+// Case13 validates "len=2" on string.
+type Case13 struct {
+	V     string `validate:"len=2"`
+	Other string
+}
+
+// Case14 validates "len=2" on []int.
+type Case14 struct {
+	V     []int `validate:"len=2"`
+	Other []int
+}
+
+// Case15 validates "len=2" on int.
+type Case15 struct {
+	V     int `validate:"len=2"`
+	Other int
+}
+
+// Case16 validates "eqfield=Other" on string.
+type Case16 struct {
+	V     string `validate:"eqfield=Other"`
+	Other string
+}
+
+// Case17 validates "eqfield=Other" on int.
+type Case17 struct {
+	V     int `validate:"eqfield=Other"`
+	Other int
+}
+
+// Case22 validates "email" on string.
+type Case22 struct {
+	V     string `validate:"email"`
+	Other string
+}
+
+// generated are the constructors of the structs of the entries, by the index of the entry.
+var generated = map[int]func() any{
+	0:  func() any { return &Case00{} },
+	1:  func() any { return &Case01{} },
+	2:  func() any { return &Case02{} },
+	3:  func() any { return &Case03{} },
+	6:  func() any { return &Case06{} },
+	7:  func() any { return &Case07{} },
+	8:  func() any { return &Case08{} },
+	9:  func() any { return &Case09{} },
+	10: func() any { return &Case10{} },
+	11: func() any { return &Case11{} },
+	12: func() any { return &Case12{} },
+	13: func() any { return &Case13{} },
+	14: func() any { return &Case14{} },
+	15: func() any { return &Case15{} },
+	16: func() any { return &Case16{} },
+	17: func() any { return &Case17{} },
+	22: func() any { return &Case22{} },
+}
+
+// notGenerated are the errors of generating the validations of the entries, by the index of the entry.
+var notGenerated = map[int]string{
+	4:  "rule \"required\": unsupported type for validation: \"required\"",
+	5:  "rule \"required\": unsupported type for validation: \"required\"",
+	18: "rule \"gte\" refers to field \"5\", which does not exist",
+	19: "rule \"gte\" refers to field \"2\", which does not exist",
+	20: "unknown rule \"gtefield\"",
+	21: "unknown rule \"omitempty\"",
+}
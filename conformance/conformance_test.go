@@ -0,0 +1,126 @@
+//go:generate go run ./gen
+package conformance_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/paluszkiewiczB/validator/conformance"
+	"github.com/paluszkiewiczB/validator/validation"
+)
+
+var update = flag.Bool("update", false, "update the compatibility matrix in the README")
+
+const (
+	readme      = "../README.md"
+	matrixBegin = "<!-- conformance:begin -->\n"
+	matrixEnd   = "<!-- conformance:end -->\n"
+
+	compatibleStatus = "compatible"
+)
+
+// Test_Conformance validates the inputs of the corpus with the generated code and reports every divergence
+// from go-playground/validator. Divergences of the entries, which the compatibility matrix in the README lists
+// as compatible, fail the test even with -update. The known divergences are logged.
+// It fails, when the compatibility matrix does not match the results, run it with -update to render the matrix again.
+func Test_Conformance(t *testing.T) {
+	entries, err := conformance.LoadCorpus("testdata/corpus.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := os.ReadFile(readme)
+	if err != nil {
+		t.Fatalf("reading README: %v", err)
+	}
+
+	before, rest, ok := strings.Cut(string(content), matrixBegin)
+	current, after, ok2 := strings.Cut(rest, matrixEnd)
+	if !ok || !ok2 {
+		t.Fatalf("README does not contain the markers of the compatibility matrix")
+	}
+
+	matrix := &bytes.Buffer{}
+	matrix.WriteString("| Rule | Type | Tag | Status |\n| --- | --- | --- | --- |\n")
+	for i, e := range entries {
+		row := fmt.Sprintf("| `%s` | `%s` | `%s` | ", e.Rule, e.Type, e.Tag)
+		compatible := strings.Contains(current, row+compatibleStatus+" |\n")
+		divergences := diverge(t, i, e)
+		for _, d := range divergences {
+			if compatible {
+				t.Errorf("%s on %s is listed as compatible: %s", e.Tag, e.Type, d)
+				continue
+			}
+
+			t.Logf("%s on %s: %s", e.Tag, e.Type, d)
+		}
+
+		status := compatibleStatus
+		if len(divergences) != 0 {
+			status = strings.Join(divergences, "<br>")
+		}
+
+		fmt.Fprintf(matrix, "%s%s |\n", row, status)
+	}
+
+	if t.Failed() {
+		return
+	}
+
+	if current == matrix.String() {
+		return
+	}
+
+	if !*update {
+		t.Fatalf("compatibility matrix in the README is out of date, run: go test ./conformance -update\n%s", matrix)
+	}
+
+	if err := os.WriteFile(readme, []byte(before+matrixBegin+matrix.String()+matrixEnd+after), 0o644); err != nil {
+		t.Fatalf("updating README: %v", err)
+	}
+}
+
+// diverge returns the divergences of the generated code from the expected results of the i-th entry.
+func diverge(t *testing.T, i int, e conformance.Entry) []string {
+	if msg, ok := notGenerated[i]; ok {
+		return []string{"not generated: " + msg}
+	}
+
+	var out []string
+	for _, in := range e.Inputs {
+		v := generated[i]()
+		if err := json.Unmarshal(in.Value, v); err != nil {
+			t.Fatalf("decoding input %s of %s: %v", in.Value, conformance.StructName(i), err)
+		}
+
+		got := ""
+		if err := v.(interface{ Validate() error }).Validate(); err != nil {
+			got = err.Error()
+			var fieldErr *validation.FieldError
+			if errors.As(err, &fieldErr) {
+				got = fieldErr.Tag
+			}
+		}
+
+		if got != in.Tag {
+			out = append(out, fmt.Sprintf("`%s`: expected %s, got %s", in.Value, result(in.Tag), result(got)))
+		}
+	}
+
+	return out
+}
+
+// result describes the tag of the error, empty tag is the valid value.
+func result(tag string) string {
+	if tag == "" {
+		return "valid"
+	}
+
+	return "`" + tag + "`"
+}
@@ -0,0 +1,50 @@
+// Package conformance contains the corpus of the rules of go-playground/validator with their expected results,
+// see testdata/corpus.json. The test of the package generates the validations of the corpus and reports every
+// divergence of the generated code, the compatibility matrix in the README is rendered from its results.
+package conformance
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Entry is the rule of go-playground/validator, validated on the field V of the Type.
+// The struct has also the field Other of the same Type, which the cross-field rules refer to.
+type Entry struct {
+	// Rule is the name of the rule, e.g. 'min'.
+	Rule string `json:"rule"`
+	// Type is the type of the fields, e.g. '[]string'.
+	Type string `json:"type"`
+	// Tag is the value of the validate tag of the field V, e.g. 'omitempty,min=2'.
+	Tag string `json:"tag"`
+	// Inputs are the values of the struct with the results of go-playground/validator.
+	Inputs []Input `json:"inputs"`
+}
+
+// Input is the JSON value of the struct, e.g. {"V": "a", "Other": "b"}.
+type Input struct {
+	Value json.RawMessage `json:"value"`
+	// Tag is the tag of the error returned by go-playground/validator, or empty when the value is valid.
+	Tag string `json:"tag,omitempty"`
+}
+
+// StructName returns the name of the struct of the i-th entry.
+func StructName(i int) string {
+	return fmt.Sprintf("Case%02d", i)
+}
+
+// LoadCorpus reads the entries from the JSON file.
+func LoadCorpus(path string) ([]Entry, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading corpus: %w", err)
+	}
+
+	var entries []Entry
+	if err := json.Unmarshal(content, &entries); err != nil {
+		return nil, fmt.Errorf("parsing corpus: %q, %w", path, err)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,121 @@
+// Command gen generates the structs of the entries of the conformance corpus and their validations.
+// Entries, which the validations can not be generated for, are listed with the errors instead.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"strconv"
+
+	"github.com/paluszkiewiczB/validator/conformance"
+	"github.com/paluszkiewiczB/validator/internal"
+)
+
+var (
+	corpus   = flag.String("corpus", "testdata/corpus.json", "input JSON file with the corpus")
+	casesOut = flag.String("cases", "cases_test.go", "output file with the structs of the entries")
+	dstFile  = flag.String("out", "validations_test.go", "output file with the validations of the structs")
+	dstPkg   = flag.String("outpkg", "conformance_test", "output package")
+)
+
+func main() {
+	flag.Parse()
+
+	entries := Must2(conformance.LoadCorpus(*corpus))
+	cases := &bytes.Buffer{}
+	fmt.Fprintf(cases, "// Code generated by validator. DO NOT EDIT.\n\npackage %s\n", *dstPkg)
+
+	var structs []internal.Struct
+	generated := map[int]bool{}
+	failed := map[int]string{}
+	for i, e := range entries {
+		src := fmt.Sprintf("\n// %s validates %q on %s.\ntype %s struct {\n\tV %s `validate:%q`\n\tOther %s\n}\n", conformance.StructName(i), e.Tag, e.Type, conformance.StructName(i), e.Type, e.Tag, e.Type)
+		str, err := generate(src, conformance.StructName(i), e.Type, e.Tag)
+		if err != nil {
+			failed[i] = err.Error()
+			continue
+		}
+
+		cases.WriteString(src)
+		structs = append(structs, str)
+		generated[i] = true
+	}
+
+	cases.WriteString("\n// generated are the constructors of the structs of the entries, by the index of the entry.\nvar generated = map[int]func() any{\n")
+	for i := range entries {
+		if generated[i] {
+			fmt.Fprintf(cases, "%d: func() any { return &%s{} },\n", i, conformance.StructName(i))
+		}
+	}
+	cases.WriteString("}\n\n// notGenerated are the errors of generating the validations of the entries, by the index of the entry.\nvar notGenerated = map[int]string{\n")
+	for i := range entries {
+		if msg, ok := failed[i]; ok {
+			fmt.Fprintf(cases, "%d: %q,\n", i, msg)
+		}
+	}
+	cases.WriteString("}\n")
+
+	Must(os.WriteFile(*casesOut, Must2(format.Source(cases.Bytes())), 0o600))
+	Must(os.WriteFile(*dstFile, Must2(internal.GenerateFile(structs, *dstPkg)), 0o600))
+}
+
+// generate returns the struct with the name declared in the src, when its validations of the tag can be generated.
+func generate(src, name, typ, tag string) (internal.Struct, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "corpus", "package p\n"+src, parser.ParseComments)
+	if err != nil {
+		return internal.Struct{}, err
+	}
+
+	groups, err := internal.ParseGroups("`validate:" + strconv.Quote(tag) + "`")
+	if err != nil {
+		return internal.Struct{}, err
+	}
+
+	// the problems are reported like the analyzer reports them, not with the positions in the generated source
+	str := internal.Struct{Name: name}
+	field := internal.Field{Name: "V", Type: internal.Type(typ)}
+	for _, alts := range groups[internal.DefaultGroup] {
+		for _, rule := range alts {
+			gen := internal.GeneratorFor(rule.Name)
+			if gen == nil {
+				return internal.Struct{}, fmt.Errorf("unknown rule %q", rule.Name)
+			}
+
+			if _, err := gen.Generate(rule, str, field); err != nil {
+				return internal.Struct{}, fmt.Errorf("rule %q: %w", rule.Name, err)
+			}
+
+			if (rule.Name == internal.Eqfield || rule.Name == internal.Gte) && rule.Param != "Other" {
+				return internal.Struct{}, fmt.Errorf("rule %q refers to field %q, which does not exist", rule.Name, rule.Param)
+			}
+		}
+	}
+
+	structs, err := internal.FindStructs(fset, f)
+	if err != nil {
+		return internal.Struct{}, err
+	}
+
+	if _, err := internal.GenerateFile(structs, "p"); err != nil {
+		return internal.Struct{}, err
+	}
+
+	return structs[0], nil
+}
+
+func Must(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
+
+func Must2[V any](val V, err error) V {
+	Must(err)
+	return val
+}
@@ -0,0 +1,144 @@
+// Code generated by validator. DO NOT EDIT.
+
+package conformance_test
+
+import (
+	"github.com/paluszkiewiczB/validator/validation"
+	"unicode/utf8"
+)
+
+// Validate implements Validator.
+func (c Case00) Validate() error {
+	if len(c.V) == 0 {
+		return &validation.FieldError{Struct: "Case00", Field: "V", StructField: "V", Tag: "required", ActualTag: "required", Param: "", Value: c.V, Message: "field \"V\" is required"}
+	}
+	return nil
+}
+
+// Validate implements Validator.
+func (c Case01) Validate() error {
+	if c.V == nil {
+		return &validation.FieldError{Struct: "Case01", Field: "V", StructField: "V", Tag: "required", ActualTag: "required", Param: "", Value: c.V, Message: "field \"V\" is required"}
+	}
+	return nil
+}
+
+// Validate implements Validator.
+func (c Case02) Validate() error {
+	if len(c.V) == 0 {
+		return &validation.FieldError{Struct: "Case02", Field: "V", StructField: "V", Tag: "required", ActualTag: "required", Param: "", Value: c.V, Message: "field \"V\" is required"}
+	}
+	return nil
+}
+
+// Validate implements Validator.
+func (c Case03) Validate() error {
+	if len(c.V) == 0 {
+		return &validation.FieldError{Struct: "Case03", Field: "V", StructField: "V", Tag: "required", ActualTag: "required", Param: "", Value: c.V, Message: "field \"V\" is required"}
+	}
+	return nil
+}
+
+// Validate implements Validator.
+func (c Case06) Validate() error {
+	if utf8.RuneCountInString(c.V) < 2 {
+		return &validation.FieldError{Struct: "Case06", Field: "V", StructField: "V", Tag: "min", ActualTag: "min", Param: "2", Value: c.V, Message: "field \"V\" must be at least 2"}
+	}
+	return nil
+}
+
+// Validate implements Validator.
+func (c Case07) Validate() error {
+	if c.V < 2 {
+		return &validation.FieldError{Struct: "Case07", Field: "V", StructField: "V", Tag: "min", ActualTag: "min", Param: "2", Value: c.V, Message: "field \"V\" must be at least 2"}
+	}
+	return nil
+}
+
+// Validate implements Validator.
+func (c Case08) Validate() error {
+	if !(c.V >= 0.5) {
+		return &validation.FieldError{Struct: "Case08", Field: "V", StructField: "V", Tag: "min", ActualTag: "min", Param: "0.5", Value: c.V, Message: "field \"V\" must be at least 0.5"}
+	}
+	return nil
+}
+
+// Validate implements Validator.
+func (c Case09) Validate() error {
+	if len(c.V) < 1 {
+		return &validation.FieldError{Struct: "Case09", Field: "V", StructField: "V", Tag: "min", ActualTag: "min", Param: "1", Value: c.V, Message: "field \"V\" must be at least 1"}
+	}
+	return nil
+}
+
+// Validate implements Validator.
+func (c Case10) Validate() error {
+	if utf8.RuneCountInString(c.V) > 2 {
+		return &validation.FieldError{Struct: "Case10", Field: "V", StructField: "V", Tag: "max", ActualTag: "max", Param: "2", Value: c.V, Message: "field \"V\" must be at most 2"}
+	}
+	return nil
+}
+
+// Validate implements Validator.
+func (c Case11) Validate() error {
+	if c.V > 10 {
+		return &validation.FieldError{Struct: "Case11", Field: "V", StructField: "V", Tag: "max", ActualTag: "max", Param: "10", Value: c.V, Message: "field \"V\" must be at most 10"}
+	}
+	return nil
+}
+
+// Validate implements Validator.
+func (c Case12) Validate() error {
+	if len(c.V) > 1 {
+		return &validation.FieldError{Struct: "Case12", Field: "V", StructField: "V", Tag: "max", ActualTag: "max", Param: "1", Value: c.V, Message: "field \"V\" must be at most 1"}
+	}
+	return nil
+}
+
+// Validate implements Validator.
+func (c Case13) Validate() error {
+	if utf8.RuneCountInString(c.V) != 2 {
+		return &validation.FieldError{Struct: "Case13", Field: "V", StructField: "V", Tag: "len", ActualTag: "len", Param: "2", Value: c.V, Message: "field \"V\" must have length 2"}
+	}
+	return nil
+}
+
+// Validate implements Validator.
+func (c Case14) Validate() error {
+	if len(c.V) != 2 {
+		return &validation.FieldError{Struct: "Case14", Field: "V", StructField: "V", Tag: "len", ActualTag: "len", Param: "2", Value: c.V, Message: "field \"V\" must have length 2"}
+	}
+	return nil
+}
+
+// Validate implements Validator.
+func (c Case15) Validate() error {
+	if c.V != 2 {
+		return &validation.FieldError{Struct: "Case15", Field: "V", StructField: "V", Tag: "len", ActualTag: "len", Param: "2", Value: c.V, Message: "field \"V\" must have length 2"}
+	}
+	return nil
+}
+
+// Validate implements Validator.
+func (c Case16) Validate() error {
+	if c.V != c.Other {
+		return &validation.FieldError{Struct: "Case16", Field: "V", StructField: "V", Tag: "eqfield", ActualTag: "eqfield", Param: "Other", Value: c.V, Message: "field \"V\" must be equal to \"Other\""}
+	}
+	return nil
+}
+
+// Validate implements Validator.
+func (c Case17) Validate() error {
+	if c.V != c.Other {
+		return &validation.FieldError{Struct: "Case17", Field: "V", StructField: "V", Tag: "eqfield", ActualTag: "eqfield", Param: "Other", Value: c.V, Message: "field \"V\" must be equal to \"Other\""}
+	}
+	return nil
+}
+
+// Validate implements Validator.
+func (c Case22) Validate() error {
+	if !(validation.IsEmail(c.V)) {
+		return &validation.FieldError{Struct: "Case22", Field: "V", StructField: "V", Tag: "email", ActualTag: "email", Param: "", Value: c.V, Message: "field \"V\" must be an email address"}
+	}
+	return nil
+}
@@ -0,0 +1,638 @@
+// Code generated by validator. DO NOT EDIT.
+
+package main_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/paluszkiewiczB/validator/validation"
+)
+
+func fixtureAccount() Account {
+	return Account{
+		Email: "a",
+	}
+}
+
+func Test_Account_Validate(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		v := fixtureAccount()
+		if err := v.Validate(); err != nil {
+			t.Errorf("expected no error, got: %v", err)
+		}
+	})
+
+	cases := []struct {
+		name   string
+		mutate func(v *Account)
+		field  string
+		tag    string
+	}{
+		{name: "Email required", mutate: func(v *Account) { v.Email = "" }, field: "Email", tag: "required"},
+		{name: "Email max=254", mutate: func(v *Account) {
+			v.Email = "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+		}, field: "Email", tag: "max"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			v := fixtureAccount()
+			c.mutate(&v)
+
+			var fieldErr *validation.FieldError
+			if err := v.Validate(); !errors.As(err, &fieldErr) || fieldErr.StructField != c.field || fieldErr.Tag != c.tag {
+				t.Errorf("expected error of field %q and tag %q, got: %v", c.field, c.tag, err)
+			}
+		})
+	}
+}
+
+func fixtureAliases() Aliases {
+	return Aliases{
+		Code: "aaa",
+	}
+}
+
+func Test_Aliases_Validate(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		v := fixtureAliases()
+		if err := v.Validate(); err != nil {
+			t.Errorf("expected no error, got: %v", err)
+		}
+	})
+
+	cases := []struct {
+		name   string
+		mutate func(v *Aliases)
+		field  string
+		tag    string
+	}{
+		{name: "Name max=3", mutate: func(v *Aliases) { v.Name = "aaaa" }, field: "Name", tag: "short"},
+		{name: "Code required", mutate: func(v *Aliases) { v.Code = "" }, field: "Code", tag: "code"},
+		{name: "Code len=3", mutate: func(v *Aliases) { v.Code = "aaaa" }, field: "Code", tag: "code"},
+		{name: "Nick len=0|max=3", mutate: func(v *Aliases) { v.Nick = "aaaa" }, field: "Nick", tag: "len|short"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			v := fixtureAliases()
+			c.mutate(&v)
+
+			var fieldErr *validation.FieldError
+			if err := v.Validate(); !errors.As(err, &fieldErr) || fieldErr.StructField != c.field || fieldErr.Tag != c.tag {
+				t.Errorf("expected error of field %q and tag %q, got: %v", c.field, c.tag, err)
+			}
+		})
+	}
+}
+
+func fixtureAlternatives() Alternatives {
+	return Alternatives{}
+}
+
+func Test_Alternatives_Validate(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		v := fixtureAlternatives()
+		if err := v.Validate(); err != nil {
+			t.Errorf("expected no error, got: %v", err)
+		}
+	})
+
+	cases := []struct {
+		name   string
+		mutate func(v *Alternatives)
+		field  string
+		tag    string
+	}{
+		// Primary required|eqfield=Backup: skipped, it can not be broken alone
+		{name: "Score gte=Min|gte=Max", mutate: func(v *Alternatives) { v.Score = -1 }, field: "Score", tag: "gte|gte"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			v := fixtureAlternatives()
+			c.mutate(&v)
+
+			var fieldErr *validation.FieldError
+			if err := v.Validate(); !errors.As(err, &fieldErr) || fieldErr.StructField != c.field || fieldErr.Tag != c.tag {
+				t.Errorf("expected error of field %q and tag %q, got: %v", c.field, c.tag, err)
+			}
+		})
+	}
+}
+
+// Count: tests skipped, generic struct can not be instantiated without the type arguments
+
+func fixtureEqfield() Eqfield {
+	return Eqfield{}
+}
+
+func Test_Eqfield_Validate(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		v := fixtureEqfield()
+		if err := v.Validate(); err != nil {
+			t.Errorf("expected no error, got: %v", err)
+		}
+	})
+
+	cases := []struct {
+		name   string
+		mutate func(v *Eqfield)
+		field  string
+		tag    string
+	}{
+		{name: "Field2 eqfield=Field1", mutate: func(v *Eqfield) { v.Field2 = "b" }, field: "Field2", tag: "eqfield"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			v := fixtureEqfield()
+			c.mutate(&v)
+
+			var fieldErr *validation.FieldError
+			if err := v.Validate(); !errors.As(err, &fieldErr) || fieldErr.StructField != c.field || fieldErr.Tag != c.tag {
+				t.Errorf("expected error of field %q and tag %q, got: %v", c.field, c.tag, err)
+			}
+		})
+	}
+}
+
+func fixtureFormats() Formats {
+	return Formats{
+		Status: "new",
+		Level:  1,
+		Code:   "AA0",
+		Email:  "a@example.com",
+		Name:   "a",
+		Tags:   []string{"a"},
+	}
+}
+
+func Test_Formats_Validate(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		v := fixtureFormats()
+		if err := v.Validate(); err != nil {
+			t.Errorf("expected no error, got: %v", err)
+		}
+	})
+
+	cases := []struct {
+		name   string
+		mutate func(v *Formats)
+		field  string
+		tag    string
+	}{
+		{name: "Status oneof=new 'in progress'", mutate: func(v *Formats) { v.Status = "" }, field: "Status", tag: "oneof"},
+		{name: "Level oneof=1 2 -3", mutate: func(v *Formats) { v.Level = 0 }, field: "Level", tag: "oneof"},
+		{name: "Code regexp=^[A-Z]{2}[0-9]+$", mutate: func(v *Formats) { v.Code = "" }, field: "Code", tag: "regexp"},
+		{name: "Email email", mutate: func(v *Formats) { v.Email = "" }, field: "Email", tag: "email"},
+		{name: "Name required", mutate: func(v *Formats) { v.Name = "" }, field: "Name", tag: "required"},
+		{name: "Tags required", mutate: func(v *Formats) { v.Tags = nil }, field: "Tags", tag: "required"},
+		{name: "Tags max=3", mutate: func(v *Formats) { v.Tags = make([]string, 4) }, field: "Tags", tag: "max"},
+		{name: "Tags[0] required", mutate: func(v *Formats) { v.Tags[0] = "" }, field: "Tags[0]", tag: "required"},
+		{name: "Tags[0] max=4", mutate: func(v *Formats) { v.Tags[0] = "aaaaa" }, field: "Tags[0]", tag: "max"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			v := fixtureFormats()
+			c.mutate(&v)
+
+			var fieldErr *validation.FieldError
+			if err := v.Validate(); !errors.As(err, &fieldErr) || fieldErr.StructField != c.field || fieldErr.Tag != c.tag {
+				t.Errorf("expected error of field %q and tag %q, got: %v", c.field, c.tag, err)
+			}
+		})
+	}
+}
+
+func fixtureGroups() Groups {
+	return Groups{
+		Password: "a",
+	}
+}
+
+func Test_Groups_Validate(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		v := fixtureGroups()
+		if err := v.Validate(); err != nil {
+			t.Errorf("expected no error, got: %v", err)
+		}
+	})
+
+	cases := []struct {
+		name   string
+		mutate func(v *Groups)
+		field  string
+		tag    string
+	}{
+		{name: "Password required", mutate: func(v *Groups) { v.Password = "" }, field: "Password", tag: "required"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			v := fixtureGroups()
+			c.mutate(&v)
+
+			var fieldErr *validation.FieldError
+			if err := v.Validate(); !errors.As(err, &fieldErr) || fieldErr.StructField != c.field || fieldErr.Tag != c.tag {
+				t.Errorf("expected error of field %q and tag %q, got: %v", c.field, c.tag, err)
+			}
+		})
+	}
+}
+
+func fixtureGroupsCreate() Groups {
+	return Groups{
+		Password: "a",
+		Repeated: "a",
+	}
+}
+
+func Test_Groups_ValidateGroupCreate(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		v := fixtureGroupsCreate()
+		if err := v.ValidateGroup("create"); err != nil {
+			t.Errorf("expected no error, got: %v", err)
+		}
+	})
+
+	cases := []struct {
+		name   string
+		mutate func(v *Groups)
+		field  string
+		tag    string
+	}{
+		{name: "Password required", mutate: func(v *Groups) { v.Password = "" }, field: "Password", tag: "required"},
+		{name: "Password eqfield=Repeated", mutate: func(v *Groups) { v.Password = "ab" }, field: "Password", tag: "eqfield"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			v := fixtureGroupsCreate()
+			c.mutate(&v)
+
+			var fieldErr *validation.FieldError
+			if err := v.ValidateGroup("create"); !errors.As(err, &fieldErr) || fieldErr.StructField != c.field || fieldErr.Tag != c.tag {
+				t.Errorf("expected error of field %q and tag %q, got: %v", c.field, c.tag, err)
+			}
+		})
+	}
+}
+
+func fixtureGroupsUpdate() Groups {
+	return Groups{
+		Password: "a",
+		Email:    new(string),
+	}
+}
+
+func Test_Groups_ValidateGroupUpdate(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		v := fixtureGroupsUpdate()
+		if err := v.ValidateGroup("update"); err != nil {
+			t.Errorf("expected no error, got: %v", err)
+		}
+	})
+
+	cases := []struct {
+		name   string
+		mutate func(v *Groups)
+		field  string
+		tag    string
+	}{
+		{name: "Password required", mutate: func(v *Groups) { v.Password = "" }, field: "Password", tag: "required"},
+		{name: "Email required", mutate: func(v *Groups) { v.Email = nil }, field: "Email", tag: "required"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			v := fixtureGroupsUpdate()
+			c.mutate(&v)
+
+			var fieldErr *validation.FieldError
+			if err := v.ValidateGroup("update"); !errors.As(err, &fieldErr) || fieldErr.StructField != c.field || fieldErr.Tag != c.tag {
+				t.Errorf("expected error of field %q and tag %q, got: %v", c.field, c.tag, err)
+			}
+		})
+	}
+}
+
+func fixtureGte() Gte {
+	return Gte{}
+}
+
+func Test_Gte_Validate(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		v := fixtureGte()
+		if err := v.Validate(); err != nil {
+			t.Errorf("expected no error, got: %v", err)
+		}
+	})
+
+	cases := []struct {
+		name   string
+		mutate func(v *Gte)
+		field  string
+		tag    string
+	}{
+		{name: "Two gte=One", mutate: func(v *Gte) { v.Two = -1 }, field: "Two", tag: "gte"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			v := fixtureGte()
+			c.mutate(&v)
+
+			var fieldErr *validation.FieldError
+			if err := v.Validate(); !errors.As(err, &fieldErr) || fieldErr.StructField != c.field || fieldErr.Tag != c.tag {
+				t.Errorf("expected error of field %q and tag %q, got: %v", c.field, c.tag, err)
+			}
+		})
+	}
+}
+
+func fixtureLength() Length {
+	return Length{
+		Name:   "aa",
+		Code:   "aaa",
+		Labels: map[string]int{"0": *new(int)},
+		Count:  1,
+	}
+}
+
+func Test_Length_Validate(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		v := fixtureLength()
+		if err := v.Validate(); err != nil {
+			t.Errorf("expected no error, got: %v", err)
+		}
+	})
+
+	cases := []struct {
+		name   string
+		mutate func(v *Length)
+		field  string
+		tag    string
+	}{
+		{name: "Name min=2", mutate: func(v *Length) { v.Name = "a" }, field: "Name", tag: "min"},
+		{name: "Name max=4", mutate: func(v *Length) { v.Name = "aaaaa" }, field: "Name", tag: "max"},
+		{name: "Code len=3", mutate: func(v *Length) { v.Code = "aaaa" }, field: "Code", tag: "len"},
+		{name: "Tags max=2", mutate: func(v *Length) { v.Tags = make([]string, 3) }, field: "Tags", tag: "max"},
+		{name: "Labels min=1", mutate: func(v *Length) { v.Labels = nil }, field: "Labels", tag: "min"},
+		{name: "Count min=1", mutate: func(v *Length) { v.Count = 0 }, field: "Count", tag: "min"},
+		{name: "Count max=10", mutate: func(v *Length) { v.Count = 11 }, field: "Count", tag: "max"},
+		{name: "Ratio max=0.5", mutate: func(v *Length) { v.Ratio = 1.5 }, field: "Ratio", tag: "max"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			v := fixtureLength()
+			c.mutate(&v)
+
+			var fieldErr *validation.FieldError
+			if err := v.Validate(); !errors.As(err, &fieldErr) || fieldErr.StructField != c.field || fieldErr.Tag != c.tag {
+				t.Errorf("expected error of field %q and tag %q, got: %v", c.field, c.tag, err)
+			}
+		})
+	}
+}
+
+func fixtureMarkers() Markers {
+	return Markers{
+		Name: "a",
+		Code: "aaa",
+	}
+}
+
+func Test_Markers_Validate(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		v := fixtureMarkers()
+		if err := v.Validate(); err != nil {
+			t.Errorf("expected no error, got: %v", err)
+		}
+	})
+
+	cases := []struct {
+		name   string
+		mutate func(v *Markers)
+		field  string
+		tag    string
+	}{
+		{name: "Name required", mutate: func(v *Markers) { v.Name = "" }, field: "Name", tag: "required"},
+		{name: "Code required", mutate: func(v *Markers) { v.Code = "" }, field: "Code", tag: "required"},
+		{name: "Code len=3", mutate: func(v *Markers) { v.Code = "aaaa" }, field: "Code", tag: "len"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			v := fixtureMarkers()
+			c.mutate(&v)
+
+			var fieldErr *validation.FieldError
+			if err := v.Validate(); !errors.As(err, &fieldErr) || fieldErr.StructField != c.field || fieldErr.Tag != c.tag {
+				t.Errorf("expected error of field %q and tag %q, got: %v", c.field, c.tag, err)
+			}
+		})
+	}
+}
+
+func fixtureMessages() Messages {
+	return Messages{
+		Password: "a",
+		Repeated: "a",
+	}
+}
+
+func Test_Messages_Validate(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		v := fixtureMessages()
+		if err := v.Validate(); err != nil {
+			t.Errorf("expected no error, got: %v", err)
+		}
+	})
+
+	cases := []struct {
+		name   string
+		mutate func(v *Messages)
+		field  string
+		tag    string
+	}{
+		{name: "Password required", mutate: func(v *Messages) { v.Password = "" }, field: "Password", tag: "required"},
+		{name: "Repeated eqfield=Password", mutate: func(v *Messages) { v.Repeated = "ab" }, field: "Repeated", tag: "eqfield"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			v := fixtureMessages()
+			c.mutate(&v)
+
+			var fieldErr *validation.FieldError
+			if err := v.Validate(); !errors.As(err, &fieldErr) || fieldErr.StructField != c.field || fieldErr.Tag != c.tag {
+				t.Errorf("expected error of field %q and tag %q, got: %v", c.field, c.tag, err)
+			}
+		})
+	}
+}
+
+func fixtureNames() Names {
+	return Names{
+		StringPointer: new(string),
+		Ignored:       new(string),
+		Dash:          new(string),
+		Unnamed:       new(string),
+	}
+}
+
+func Test_Names_Validate(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		v := fixtureNames()
+		if err := v.Validate(); err != nil {
+			t.Errorf("expected no error, got: %v", err)
+		}
+	})
+
+	cases := []struct {
+		name   string
+		mutate func(v *Names)
+		field  string
+		tag    string
+	}{
+		{name: "StringPointer required", mutate: func(v *Names) { v.StringPointer = nil }, field: "StringPointer", tag: "required"},
+		{name: "Ignored required", mutate: func(v *Names) { v.Ignored = nil }, field: "Ignored", tag: "required"},
+		{name: "Dash required", mutate: func(v *Names) { v.Dash = nil }, field: "Dash", tag: "required"},
+		{name: "Unnamed required", mutate: func(v *Names) { v.Unnamed = nil }, field: "Unnamed", tag: "required"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			v := fixtureNames()
+			c.mutate(&v)
+
+			var fieldErr *validation.FieldError
+			if err := v.Validate(); !errors.As(err, &fieldErr) || fieldErr.StructField != c.field || fieldErr.Tag != c.tag {
+				t.Errorf("expected error of field %q and tag %q, got: %v", c.field, c.tag, err)
+			}
+		})
+	}
+}
+
+// Page: tests skipped, generic struct can not be instantiated without the type arguments
+
+func fixturePassword() Password {
+	return Password{
+		Value: "aaaaaaaa",
+	}
+}
+
+func Test_Password_Validate(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		v := fixturePassword()
+		if err := v.validate(); err != nil {
+			t.Errorf("expected no error, got: %v", err)
+		}
+	})
+
+	cases := []struct {
+		name   string
+		mutate func(v *Password)
+		field  string
+		tag    string
+	}{
+		{name: "Value min=8", mutate: func(v *Password) { v.Value = "aaaaaaa" }, field: "Value", tag: "min"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			v := fixturePassword()
+			c.mutate(&v)
+
+			var fieldErr *validation.FieldError
+			if err := v.validate(); !errors.As(err, &fieldErr) || fieldErr.StructField != c.field || fieldErr.Tag != c.tag {
+				t.Errorf("expected error of field %q and tag %q, got: %v", c.field, c.tag, err)
+			}
+		})
+	}
+}
+
+// Range: tests skipped, generic struct can not be instantiated without the type arguments
+
+func fixtureRequired() Required {
+	return Required{
+		String:        "a",
+		StringPointer: new(string),
+		Slice:         make([]struct{}, 1),
+		Map:           map[string]struct{}{"0": *new(struct{})},
+	}
+}
+
+func Test_Required_Validate(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		v := fixtureRequired()
+		if err := v.Validate(); err != nil {
+			t.Errorf("expected no error, got: %v", err)
+		}
+	})
+
+	cases := []struct {
+		name   string
+		mutate func(v *Required)
+		field  string
+		tag    string
+	}{
+		{name: "String required", mutate: func(v *Required) { v.String = "" }, field: "String", tag: "required"},
+		{name: "StringPointer required", mutate: func(v *Required) { v.StringPointer = nil }, field: "StringPointer", tag: "required"},
+		{name: "Slice required", mutate: func(v *Required) { v.Slice = nil }, field: "Slice", tag: "required"},
+		{name: "Map required", mutate: func(v *Required) { v.Map = nil }, field: "Map", tag: "required"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			v := fixtureRequired()
+			c.mutate(&v)
+
+			var fieldErr *validation.FieldError
+			if err := v.Validate(); !errors.As(err, &fieldErr) || fieldErr.StructField != c.field || fieldErr.Tag != c.tag {
+				t.Errorf("expected error of field %q and tag %q, got: %v", c.field, c.tag, err)
+			}
+		})
+	}
+}
+
+func fixtureSearch() Search {
+	return Search{
+		Query: "a",
+	}
+}
+
+func Test_Search_Validate(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		v := fixtureSearch()
+		if err := v.Validate(); err != nil {
+			t.Errorf("expected no error, got: %v", err)
+		}
+	})
+
+	cases := []struct {
+		name   string
+		mutate func(v *Search)
+		field  string
+		tag    string
+	}{
+		{name: "Query required", mutate: func(v *Search) { v.Query = "" }, field: "Query", tag: "required"},
+		{name: "Size max=100", mutate: func(v *Search) { v.Size = 101 }, field: "Size", tag: "max"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			v := fixtureSearch()
+			c.mutate(&v)
+
+			var fieldErr *validation.FieldError
+			if err := v.Validate(); !errors.As(err, &fieldErr) || fieldErr.StructField != c.field || fieldErr.Tag != c.tag {
+				t.Errorf("expected error of field %q and tag %q, got: %v", c.field, c.tag, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,613 @@
+// Code generated by validator. DO NOT EDIT.
+
+package main_test
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/paluszkiewiczB/validator/validation"
+)
+
+func FuzzAccountValidate(f *testing.F) {
+	f.Add("")
+	f.Add("a")
+	f.Fuzz(func(t *testing.T, argEmail string) {
+		v := Account{}
+		v.Email = argEmail
+
+		checks := []struct {
+			violation string
+			ok        bool
+		}{
+			{"Email required", validatorSatisfies(reflect.ValueOf(v.Email), "required", "", reflect.Value{})},
+			{"Email max", validatorSatisfies(reflect.ValueOf(v.Email), "max", "254", reflect.Value{})},
+		}
+
+		expected := ""
+		for _, c := range checks {
+			if !c.ok {
+				expected = c.violation
+				break
+			}
+		}
+
+		got := ""
+		err := v.Validate()
+		var fieldErr *validation.FieldError
+		if errors.As(err, &fieldErr) {
+			got = fieldErr.StructField + " " + fieldErr.Tag
+		}
+
+		if got != expected || (err != nil && fieldErr == nil) {
+			t.Errorf("Validate of %#v returned: %v, expected violation: %q", v, err, expected)
+		}
+	})
+}
+
+func FuzzAliasesValidate(f *testing.F) {
+	f.Add("", "", "")
+	f.Add("", "aaa", "")
+	f.Fuzz(func(t *testing.T, argName string, argCode string, argNick string) {
+		v := Aliases{}
+		v.Name = argName
+		v.Code = argCode
+		v.Nick = argNick
+
+		checks := []struct {
+			violation string
+			ok        bool
+		}{
+			{"Name short", validatorSatisfies(reflect.ValueOf(v.Name), "max", "3", reflect.Value{})},
+			{"Code code", validatorSatisfies(reflect.ValueOf(v.Code), "required", "", reflect.Value{})},
+			{"Code code", validatorSatisfies(reflect.ValueOf(v.Code), "len", "3", reflect.Value{})},
+			{"Nick len|short", validatorSatisfies(reflect.ValueOf(v.Nick), "len", "0", reflect.Value{}) || validatorSatisfies(reflect.ValueOf(v.Nick), "max", "3", reflect.Value{})},
+		}
+
+		expected := ""
+		for _, c := range checks {
+			if !c.ok {
+				expected = c.violation
+				break
+			}
+		}
+
+		got := ""
+		err := v.Validate()
+		var fieldErr *validation.FieldError
+		if errors.As(err, &fieldErr) {
+			got = fieldErr.StructField + " " + fieldErr.Tag
+		}
+
+		if got != expected || (err != nil && fieldErr == nil) {
+			t.Errorf("Validate of %#v returned: %v, expected violation: %q", v, err, expected)
+		}
+	})
+}
+
+func FuzzAlternativesValidate(f *testing.F) {
+	f.Add("", "", float64(0), int(0), int(0))
+	f.Fuzz(func(t *testing.T, argPrimary string, argBackup string, argScore float64, argMin int, argMax int) {
+		v := Alternatives{}
+		v.Primary = argPrimary
+		v.Backup = argBackup
+		v.Score = argScore
+		v.Min = argMin
+		v.Max = argMax
+
+		checks := []struct {
+			violation string
+			ok        bool
+		}{
+			{"Primary required|eqfield", validatorSatisfies(reflect.ValueOf(v.Primary), "required", "", reflect.Value{}) || validatorSatisfies(reflect.ValueOf(v.Primary), "eqfield", "Backup", reflect.ValueOf(v.Backup))},
+			{"Score gte|gte", validatorSatisfies(reflect.ValueOf(v.Score), "gte", "Min", reflect.ValueOf(v.Min)) || validatorSatisfies(reflect.ValueOf(v.Score), "gte", "Max", reflect.ValueOf(v.Max))},
+		}
+
+		expected := ""
+		for _, c := range checks {
+			if !c.ok {
+				expected = c.violation
+				break
+			}
+		}
+
+		got := ""
+		err := v.Validate()
+		var fieldErr *validation.FieldError
+		if errors.As(err, &fieldErr) {
+			got = fieldErr.StructField + " " + fieldErr.Tag
+		}
+
+		if got != expected || (err != nil && fieldErr == nil) {
+			t.Errorf("Validate of %#v returned: %v, expected violation: %q", v, err, expected)
+		}
+	})
+}
+
+// Count: fuzzing skipped, generic struct can not be instantiated without the type arguments
+
+func FuzzEqfieldValidate(f *testing.F) {
+	f.Add("", "")
+	f.Fuzz(func(t *testing.T, argField2 string, argField1 string) {
+		v := Eqfield{}
+		v.Field2 = argField2
+		v.Field1 = argField1
+
+		checks := []struct {
+			violation string
+			ok        bool
+		}{
+			{"Field2 eqfield", validatorSatisfies(reflect.ValueOf(v.Field2), "eqfield", "Field1", reflect.ValueOf(v.Field1))},
+		}
+
+		expected := ""
+		for _, c := range checks {
+			if !c.ok {
+				expected = c.violation
+				break
+			}
+		}
+
+		got := ""
+		err := v.Validate()
+		var fieldErr *validation.FieldError
+		if errors.As(err, &fieldErr) {
+			got = fieldErr.StructField + " " + fieldErr.Tag
+		}
+
+		if got != expected || (err != nil && fieldErr == nil) {
+			t.Errorf("Validate of %#v returned: %v, expected violation: %q", v, err, expected)
+		}
+	})
+}
+
+// Formats: fuzzing skipped, rule "oneof" of field "Status" is not known to the independent interpreter
+
+func FuzzGroupsValidate(f *testing.F) {
+	f.Add("", true, "")
+	f.Add("a", true, "")
+	f.Fuzz(func(t *testing.T, argPassword string, argEmailNil bool, argEmail string) {
+		v := Groups{}
+		v.Password = argPassword
+		if !argEmailNil {
+			v.Email = &argEmail
+		}
+
+		checks := []struct {
+			violation string
+			ok        bool
+		}{
+			{"Password required", validatorSatisfies(reflect.ValueOf(v.Password), "required", "", reflect.Value{})},
+		}
+
+		expected := ""
+		for _, c := range checks {
+			if !c.ok {
+				expected = c.violation
+				break
+			}
+		}
+
+		got := ""
+		err := v.Validate()
+		var fieldErr *validation.FieldError
+		if errors.As(err, &fieldErr) {
+			got = fieldErr.StructField + " " + fieldErr.Tag
+		}
+
+		if got != expected || (err != nil && fieldErr == nil) {
+			t.Errorf("Validate of %#v returned: %v, expected violation: %q", v, err, expected)
+		}
+	})
+}
+
+func FuzzGteValidate(f *testing.F) {
+	f.Add(float64(0), int(0))
+	f.Fuzz(func(t *testing.T, argTwo float64, argOne int) {
+		v := Gte{}
+		v.Two = argTwo
+		v.One = argOne
+
+		checks := []struct {
+			violation string
+			ok        bool
+		}{
+			{"Two gte", validatorSatisfies(reflect.ValueOf(v.Two), "gte", "One", reflect.ValueOf(v.One))},
+		}
+
+		expected := ""
+		for _, c := range checks {
+			if !c.ok {
+				expected = c.violation
+				break
+			}
+		}
+
+		got := ""
+		err := v.Validate()
+		var fieldErr *validation.FieldError
+		if errors.As(err, &fieldErr) {
+			got = fieldErr.StructField + " " + fieldErr.Tag
+		}
+
+		if got != expected || (err != nil && fieldErr == nil) {
+			t.Errorf("Validate of %#v returned: %v, expected violation: %q", v, err, expected)
+		}
+	})
+}
+
+func FuzzLengthValidate(f *testing.F) {
+	f.Add("", "", uint8(0), uint8(0), uint8(0), float64(0))
+	f.Add("aa", "aaa", uint8(0), uint8(1), uint8(1), float64(0))
+	f.Fuzz(func(t *testing.T, argName string, argCode string, argTags uint8, argLabels uint8, argCount uint8, argRatio float64) {
+		v := Length{}
+		v.Name = argName
+		v.Code = argCode
+		v.Tags = make([]string, argTags%16)
+		v.Labels = map[string]int{}
+		for i := range int(argLabels % 16) {
+			v.Labels[strconv.Itoa(i)] = *new(int)
+		}
+		v.Count = argCount
+		v.Ratio = argRatio
+
+		checks := []struct {
+			violation string
+			ok        bool
+		}{
+			{"Name min", validatorSatisfies(reflect.ValueOf(v.Name), "min", "2", reflect.Value{})},
+			{"Name max", validatorSatisfies(reflect.ValueOf(v.Name), "max", "4", reflect.Value{})},
+			{"Code len", validatorSatisfies(reflect.ValueOf(v.Code), "len", "3", reflect.Value{})},
+			{"Tags max", validatorSatisfies(reflect.ValueOf(v.Tags), "max", "2", reflect.Value{})},
+			{"Labels min", validatorSatisfies(reflect.ValueOf(v.Labels), "min", "1", reflect.Value{})},
+			{"Count min", validatorSatisfies(reflect.ValueOf(v.Count), "min", "1", reflect.Value{})},
+			{"Count max", validatorSatisfies(reflect.ValueOf(v.Count), "max", "10", reflect.Value{})},
+			{"Ratio max", validatorSatisfies(reflect.ValueOf(v.Ratio), "max", "0.5", reflect.Value{})},
+		}
+
+		expected := ""
+		for _, c := range checks {
+			if !c.ok {
+				expected = c.violation
+				break
+			}
+		}
+
+		got := ""
+		err := v.Validate()
+		var fieldErr *validation.FieldError
+		if errors.As(err, &fieldErr) {
+			got = fieldErr.StructField + " " + fieldErr.Tag
+		}
+
+		if got != expected || (err != nil && fieldErr == nil) {
+			t.Errorf("Validate of %#v returned: %v, expected violation: %q", v, err, expected)
+		}
+	})
+}
+
+func FuzzMarkersValidate(f *testing.F) {
+	f.Add("", "")
+	f.Add("a", "aaa")
+	f.Fuzz(func(t *testing.T, argName string, argCode string) {
+		v := Markers{}
+		v.Name = argName
+		v.Code = argCode
+
+		checks := []struct {
+			violation string
+			ok        bool
+		}{
+			{"Name required", validatorSatisfies(reflect.ValueOf(v.Name), "required", "", reflect.Value{})},
+			{"Code required", validatorSatisfies(reflect.ValueOf(v.Code), "required", "", reflect.Value{})},
+			{"Code len", validatorSatisfies(reflect.ValueOf(v.Code), "len", "3", reflect.Value{})},
+		}
+
+		expected := ""
+		for _, c := range checks {
+			if !c.ok {
+				expected = c.violation
+				break
+			}
+		}
+
+		got := ""
+		err := v.Validate()
+		var fieldErr *validation.FieldError
+		if errors.As(err, &fieldErr) {
+			got = fieldErr.StructField + " " + fieldErr.Tag
+		}
+
+		if got != expected || (err != nil && fieldErr == nil) {
+			t.Errorf("Validate of %#v returned: %v, expected violation: %q", v, err, expected)
+		}
+	})
+}
+
+func FuzzMessagesValidate(f *testing.F) {
+	f.Add("", "")
+	f.Add("a", "a")
+	f.Fuzz(func(t *testing.T, argPassword string, argRepeated string) {
+		v := Messages{}
+		v.Password = argPassword
+		v.Repeated = argRepeated
+
+		checks := []struct {
+			violation string
+			ok        bool
+		}{
+			{"Password required", validatorSatisfies(reflect.ValueOf(v.Password), "required", "", reflect.Value{})},
+			{"Repeated eqfield", validatorSatisfies(reflect.ValueOf(v.Repeated), "eqfield", "Password", reflect.ValueOf(v.Password))},
+		}
+
+		expected := ""
+		for _, c := range checks {
+			if !c.ok {
+				expected = c.violation
+				break
+			}
+		}
+
+		got := ""
+		err := v.Validate()
+		var fieldErr *validation.FieldError
+		if errors.As(err, &fieldErr) {
+			got = fieldErr.StructField + " " + fieldErr.Tag
+		}
+
+		if got != expected || (err != nil && fieldErr == nil) {
+			t.Errorf("Validate of %#v returned: %v, expected violation: %q", v, err, expected)
+		}
+	})
+}
+
+func FuzzNamesValidate(f *testing.F) {
+	f.Add(true, "", true, "", true, "", true, "")
+	f.Add(false, "", false, "", false, "", false, "")
+	f.Fuzz(func(t *testing.T, argStringPointerNil bool, argStringPointer string, argIgnoredNil bool, argIgnored string, argDashNil bool, argDash string, argUnnamedNil bool, argUnnamed string) {
+		v := Names{}
+		if !argStringPointerNil {
+			v.StringPointer = &argStringPointer
+		}
+		if !argIgnoredNil {
+			v.Ignored = &argIgnored
+		}
+		if !argDashNil {
+			v.Dash = &argDash
+		}
+		if !argUnnamedNil {
+			v.Unnamed = &argUnnamed
+		}
+
+		checks := []struct {
+			violation string
+			ok        bool
+		}{
+			{"StringPointer required", validatorSatisfies(reflect.ValueOf(v.StringPointer), "required", "", reflect.Value{})},
+			{"Ignored required", validatorSatisfies(reflect.ValueOf(v.Ignored), "required", "", reflect.Value{})},
+			{"Dash required", validatorSatisfies(reflect.ValueOf(v.Dash), "required", "", reflect.Value{})},
+			{"Unnamed required", validatorSatisfies(reflect.ValueOf(v.Unnamed), "required", "", reflect.Value{})},
+		}
+
+		expected := ""
+		for _, c := range checks {
+			if !c.ok {
+				expected = c.violation
+				break
+			}
+		}
+
+		got := ""
+		err := v.Validate()
+		var fieldErr *validation.FieldError
+		if errors.As(err, &fieldErr) {
+			got = fieldErr.StructField + " " + fieldErr.Tag
+		}
+
+		if got != expected || (err != nil && fieldErr == nil) {
+			t.Errorf("Validate of %#v returned: %v, expected violation: %q", v, err, expected)
+		}
+	})
+}
+
+// Page: fuzzing skipped, generic struct can not be instantiated without the type arguments
+
+func FuzzPasswordValidate(f *testing.F) {
+	f.Add("")
+	f.Add("aaaaaaaa")
+	f.Fuzz(func(t *testing.T, argValue string) {
+		v := Password{}
+		v.Value = argValue
+
+		checks := []struct {
+			violation string
+			ok        bool
+		}{
+			{"Value min", validatorSatisfies(reflect.ValueOf(v.Value), "min", "8", reflect.Value{})},
+		}
+
+		expected := ""
+		for _, c := range checks {
+			if !c.ok {
+				expected = c.violation
+				break
+			}
+		}
+
+		got := ""
+		err := v.validate()
+		var fieldErr *validation.FieldError
+		if errors.As(err, &fieldErr) {
+			got = fieldErr.StructField + " " + fieldErr.Tag
+		}
+
+		if got != expected || (err != nil && fieldErr == nil) {
+			t.Errorf("Validate of %#v returned: %v, expected violation: %q", v, err, expected)
+		}
+	})
+}
+
+// Range: fuzzing skipped, generic struct can not be instantiated without the type arguments
+
+func FuzzRequiredValidate(f *testing.F) {
+	f.Add("", true, "", uint8(0), uint8(0))
+	f.Add("a", false, "", uint8(1), uint8(1))
+	f.Fuzz(func(t *testing.T, argString string, argStringPointerNil bool, argStringPointer string, argSlice uint8, argMap uint8) {
+		v := Required{}
+		v.String = argString
+		if !argStringPointerNil {
+			v.StringPointer = &argStringPointer
+		}
+		v.Slice = make([]struct{}, argSlice%16)
+		v.Map = map[string]struct{}{}
+		for i := range int(argMap % 16) {
+			v.Map[strconv.Itoa(i)] = *new(struct{})
+		}
+
+		checks := []struct {
+			violation string
+			ok        bool
+		}{
+			{"String required", validatorSatisfies(reflect.ValueOf(v.String), "required", "", reflect.Value{})},
+			{"StringPointer required", validatorSatisfies(reflect.ValueOf(v.StringPointer), "required", "", reflect.Value{})},
+			{"Slice required", validatorSatisfies(reflect.ValueOf(v.Slice), "required", "", reflect.Value{})},
+			{"Map required", validatorSatisfies(reflect.ValueOf(v.Map), "required", "", reflect.Value{})},
+		}
+
+		expected := ""
+		for _, c := range checks {
+			if !c.ok {
+				expected = c.violation
+				break
+			}
+		}
+
+		got := ""
+		err := v.Validate()
+		var fieldErr *validation.FieldError
+		if errors.As(err, &fieldErr) {
+			got = fieldErr.StructField + " " + fieldErr.Tag
+		}
+
+		if got != expected || (err != nil && fieldErr == nil) {
+			t.Errorf("Validate of %#v returned: %v, expected violation: %q", v, err, expected)
+		}
+	})
+}
+
+func FuzzSearchValidate(f *testing.F) {
+	f.Add("", true, "", int(0), "")
+	f.Add("a", true, "", int(0), "")
+	f.Fuzz(func(t *testing.T, argQuery string, argEmailNil bool, argEmail string, argSize int, argSort string) {
+		v := Search{}
+		v.Query = argQuery
+		if !argEmailNil {
+			v.Email = &argEmail
+		}
+		v.Size = argSize
+		v.Sort = argSort
+
+		checks := []struct {
+			violation string
+			ok        bool
+		}{
+			{"Query required", validatorSatisfies(reflect.ValueOf(v.Query), "required", "", reflect.Value{})},
+			{"Size max", validatorSatisfies(reflect.ValueOf(v.Size), "max", "100", reflect.Value{})},
+		}
+
+		expected := ""
+		for _, c := range checks {
+			if !c.ok {
+				expected = c.violation
+				break
+			}
+		}
+
+		got := ""
+		err := v.Validate()
+		var fieldErr *validation.FieldError
+		if errors.As(err, &fieldErr) {
+			got = fieldErr.StructField + " " + fieldErr.Tag
+		}
+
+		if got != expected || (err != nil && fieldErr == nil) {
+			t.Errorf("Validate of %#v returned: %v, expected violation: %q", v, err, expected)
+		}
+	})
+}
+
+// validatorSatisfies interprets the rule with the param for the value v, other is the field the rule refers to.
+// It is independent of the generated code: lengths and numbers are compared exactly with big.Float.
+func validatorSatisfies(v reflect.Value, rule, param string, other reflect.Value) bool {
+	switch rule {
+	case "required":
+		if v.Kind() == reflect.Pointer {
+			return !v.IsNil()
+		}
+		return v.Len() != 0
+	case "eqfield":
+		return v.Interface() == other.Interface()
+	}
+
+	x, ok := validatorExact(v)
+	if !ok {
+		return false
+	}
+
+	var y *big.Float
+	if rule == "gte" {
+		if y, ok = validatorExact(other); !ok {
+			return false
+		}
+	} else {
+		bits := 64
+		if v.Kind() == reflect.Float32 {
+			bits = 32
+		}
+
+		if v.CanFloat() {
+			f, _ := strconv.ParseFloat(param, bits)
+			y = new(big.Float).SetFloat64(f)
+		} else {
+			y, _ = new(big.Float).SetPrec(256).SetString(param)
+		}
+	}
+
+	switch cmp := x.Cmp(y); rule {
+	case "min", "gte":
+		return cmp >= 0
+	case "max":
+		return cmp <= 0
+	case "len":
+		return cmp == 0
+	}
+
+	panic("unknown rule: " + rule)
+}
+
+// validatorExact returns the length of strings (in runes), slices and maps, or the value of numbers.
+// It returns false for NaN, which fails all the comparisons.
+func validatorExact(v reflect.Value) (*big.Float, bool) {
+	switch {
+	case v.Kind() == reflect.String:
+		return new(big.Float).SetInt64(int64(utf8.RuneCountInString(v.String()))), true
+	case v.Kind() == reflect.Slice, v.Kind() == reflect.Map:
+		return new(big.Float).SetInt64(int64(v.Len())), true
+	case v.CanInt():
+		return new(big.Float).SetInt64(v.Int()), true
+	case v.CanUint():
+		return new(big.Float).SetUint64(v.Uint()), true
+	case v.CanFloat():
+		if math.IsNaN(v.Float()) {
+			return nil, false
+		}
+		return new(big.Float).SetFloat64(v.Float()), true
+	}
+
+	panic("unsupported kind: " + v.Kind().String())
+}
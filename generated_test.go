@@ -1,7 +1,25 @@
-//go:generate go run -trimpath . -in=generated_test.go -outpkg=main_test -out=generated_validations_test.go -debug=true
+//go:generate go run -trimpath . -in=generated_test.go -outpkg=main_test -out=generated_validations_test.go -name-tag=json -gen-tests=generated_cases_test.go -gen-fuzz=generated_fuzz_test.go -debug=true
 package main_test
 
-import "testing"
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/token"
+	"math"
+	"math/rand"
+	"reflect"
+	"regexp"
+	"slices"
+	"testing"
+	"testing/quick"
+	"unicode/utf8"
+
+	"github.com/paluszkiewiczB/validator/internal"
+	"github.com/paluszkiewiczB/validator/interpret"
+	"github.com/paluszkiewiczB/validator/validation"
+)
 
 // Validator is an interface that must be implemented by all validation structs.
 type Validator interface {
@@ -103,4 +121,610 @@ func Test_Gte(t *testing.T) {
 			t.Errorf("expected error, got nil")
 		}
 	})
+
+	t.Run("NaN", func(t *testing.T) {
+		v := Gte{One: 2, Two: math.NaN()}
+		in := interpret.Interpreter{NameTag: "json"}
+		// the errors hold NaN, which is not equal to itself, so the messages are compared
+		if err, expected := v.Validate(), in.Validate(v); err == nil || expected == nil || err.Error() != expected.Error() {
+			t.Errorf("expected error of the interpreter %v, got %v", expected, err)
+		}
+	})
+}
+
+var _ Validator = Groups{}
+
+type Groups struct {
+	Password string  `validate:"required" validate.create:"required,eqfield=Repeated"`
+	Repeated string  `json:"repeated"`
+	Email    *string `json:"email" validate:"update:required"`
+}
+
+func Test_Groups(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		v := Groups{Password: "secret", Repeated: "secret", Email: new(string)}
+		for _, group := range []string{"", "create", "update"} {
+			if err := v.ValidateGroup(group); err != nil {
+				t.Errorf("expected no error in group %q, got %v", group, err)
+			}
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		cases := map[string]struct {
+			v     Groups
+			group string
+		}{
+			"default":       {v: Groups{Repeated: "secret"}},
+			"create":        {v: Groups{Password: "secret", Repeated: "other"}, group: "create"},
+			"update":        {v: Groups{Password: "secret"}, group: "update"},
+			"unknown group": {v: Groups{Password: "secret"}, group: "unknown"},
+		}
+
+		for name, c := range cases {
+			t.Run(name, func(t *testing.T) {
+				if err := c.v.ValidateGroup(c.group); err == nil {
+					t.Errorf("expected error, got nil")
+				}
+			})
+		}
+	})
+
+	t.Run("default group ignores other groups", func(t *testing.T) {
+		v := Groups{Password: "secret", Repeated: "other"}
+		if err := v.Validate(); err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+	})
+}
+
+var _ Validator = Messages{}
+
+type Messages struct {
+	Password string `validate:"required" msg:"Password must be provided"`
+	Repeated string `validate:"eqfield=Password" msg.eqfield:"{field} must repeat {param}, got: {value}"`
+}
+
+func Test_Messages(t *testing.T) {
+	cases := map[string]struct {
+		v   Messages
+		err string
+	}{
+		"field message":      {v: Messages{Repeated: ""}, err: "Password must be provided"},
+		"validation message": {v: Messages{Password: "secret", Repeated: "100%"}, err: "Repeated must repeat Password, got: 100%"},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			if err := c.v.Validate(); err == nil || err.Error() != c.err {
+				t.Errorf("expected error %q, got %v", c.err, err)
+			}
+		})
+	}
+}
+
+func Test_Translate(t *testing.T) {
+	v := NewValidRequired()
+	v.String = ""
+	err := v.Validate()
+
+	var fe *validation.FieldError
+	if !errors.As(err, &fe) {
+		t.Fatalf("expected validation.FieldError, got %T", err)
+	}
+
+	if fe.Tag != "required" || fe.Namespace() != "Required.String" {
+		t.Errorf("unexpected field error: %#v", fe)
+	}
+
+	expected := "pole \"String\" jest wymagane"
+	if got := validation.Translate(err, "pl"); got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
+
+var _ Validator = Names{}
+
+type Names struct {
+	StringPointer *string `json:"string_pointer,omitempty" validate:"required"`
+	Ignored       *string `json:"-" validate:"required"`
+	Dash          *string `json:"-," validate:"required"`
+	Unnamed       *string `json:",omitempty" validate:"required"`
+}
+
+func Test_Names(t *testing.T) {
+	cases := map[string]struct {
+		mut         func(n *Names)
+		field       string
+		structField string
+	}{
+		"json name":    {mut: func(n *Names) { n.StringPointer = nil }, field: "string_pointer", structField: "StringPointer"},
+		"ignored":      {mut: func(n *Names) { n.Ignored = nil }, field: "Ignored", structField: "Ignored"},
+		"dash":         {mut: func(n *Names) { n.Dash = nil }, field: "-", structField: "Dash"},
+		"without name": {mut: func(n *Names) { n.Unnamed = nil }, field: "Unnamed", structField: "Unnamed"},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			v := Names{StringPointer: new(string), Ignored: new(string), Dash: new(string), Unnamed: new(string)}
+			c.mut(&v)
+
+			var fe *validation.FieldError
+			if err := v.Validate(); !errors.As(err, &fe) {
+				t.Fatalf("expected validation.FieldError, got %v", err)
+			}
+
+			if fe.Field != c.field || fe.StructField != c.structField {
+				t.Errorf("expected field %q and struct field %q, got %q and %q", c.field, c.structField, fe.Field, fe.StructField)
+			}
+
+			if expected := "field \"" + c.field + "\" is required"; fe.Error() != expected {
+				t.Errorf("expected error %q, got %q", expected, fe.Error())
+			}
+		})
+	}
+}
+
+var _ Validator = Alternatives{}
+
+type Alternatives struct {
+	Primary string `validate:"required|eqfield=Backup"`
+	Backup  string
+	Score   float64 `validate:"gte=Min|gte=Max" msg.gte|gte:"{field} is too low: {value}"`
+	Min     int
+	Max     int
+}
+
+func Test_Alternatives(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		for _, v := range []Alternatives{
+			{Primary: "primary", Backup: "backup"},
+			{Primary: "", Backup: ""},
+			{Score: 1, Min: 2, Max: 1},
+		} {
+			if err := v.Validate(); err != nil {
+				t.Errorf("expected no error for %+v, got %v", v, err)
+			}
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		cases := map[string]struct {
+			v   Alternatives
+			tag string
+			err string
+		}{
+			"none satisfied": {
+				v:   Alternatives{Primary: "", Backup: "backup"},
+				tag: "required|eqfield",
+				err: "field \"Primary\" is required or field \"Primary\" must be equal to \"Backup\"",
+			},
+			"message of alternatives": {
+				v:   Alternatives{Score: 0.5, Min: 1, Max: 2},
+				tag: "gte|gte",
+				err: "Score is too low: 0.5",
+			},
+		}
+
+		for name, c := range cases {
+			t.Run(name, func(t *testing.T) {
+				var fe *validation.FieldError
+				if err := c.v.Validate(); !errors.As(err, &fe) {
+					t.Fatalf("expected validation.FieldError, got %v", err)
+				}
+
+				if fe.Tag != c.tag || fe.Error() != c.err {
+					t.Errorf("expected tag %q and error %q, got %q and %q", c.tag, c.err, fe.Tag, fe.Error())
+				}
+			})
+		}
+	})
+}
+
+var _ Validator = Length{}
+
+type Length struct {
+	Name   string         `validate:"min=2,max=4"`
+	Code   string         `validate:"len=3"`
+	Tags   []string       `validate:"max=2"`
+	Labels map[string]int `validate:"min=1"`
+	Count  uint8          `validate:"min=1,max=10"`
+	Ratio  float64        `validate:"max=0.5"`
 }
+
+func Test_Length(t *testing.T) {
+	valid := func() Length {
+		return Length{Name: "żółw", Code: "abc", Tags: []string{"a"}, Labels: map[string]int{"a": 1}, Count: 10, Ratio: 0.5}
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		if err := valid().Validate(); err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		cases := map[string]func(l *Length){
+			"name too short": func(l *Length) { l.Name = "a" },
+			"name too long":  func(l *Length) { l.Name = "abcde" },
+			"code length":    func(l *Length) { l.Code = "ab" },
+			"too many tags":  func(l *Length) { l.Tags = []string{"a", "b", "c"} },
+			"no labels":      func(l *Length) { l.Labels = nil },
+			"zero count":     func(l *Length) { l.Count = 0 },
+			"big ratio":      func(l *Length) { l.Ratio = 0.6 },
+			"NaN ratio":      func(l *Length) { l.Ratio = math.NaN() },
+		}
+
+		for name, modify := range cases {
+			t.Run(name, func(t *testing.T) {
+				v := valid()
+				modify(&v)
+				if err := v.Validate(); err == nil {
+					t.Errorf("expected error, got nil")
+				}
+			})
+		}
+	})
+}
+
+// Test_Interpreter checks that the interpreter and the generated code agree on the zero and random instances.
+func Test_Interpreter(t *testing.T) {
+	in := interpret.Interpreter{NameTag: "json"}
+	r := rand.New(rand.NewSource(1))
+	for _, v := range []Validator{Required{}, Eqfield{}, Gte{}, Groups{}, Messages{}, Names{}, Alternatives{}, Length{}, Formats{}} {
+		typ := reflect.TypeOf(v)
+		t.Run(typ.Name(), func(t *testing.T) {
+			for i := range 1000 {
+				if i != 0 {
+					random, ok := quick.Value(typ, r)
+					if !ok {
+						t.Fatalf("generating random %s", typ)
+					}
+					v = random.Interface().(Validator)
+				}
+
+				if expected, got := v.Validate(), in.Validate(v); !reflect.DeepEqual(expected, got) {
+					t.Fatalf("validating %#v, expected: %#v, got: %#v", v, expected, got)
+				}
+
+				g, ok := v.(interface{ ValidateGroup(string) error })
+				if !ok {
+					continue
+				}
+
+				for _, group := range []string{"create", "update", "unknown"} {
+					if expected, got := g.ValidateGroup(group), in.ValidateGroup(v, group); !reflect.DeepEqual(expected, got) {
+						t.Fatalf("validating %#v in group %q, expected: %#v, got: %#v", v, group, expected, got)
+					}
+				}
+			}
+		})
+	}
+}
+
+var _ Validator = Markers{}
+
+type Markers struct {
+	// Name of the markers.
+	// +validate:required
+	Name string
+	Code string `validate:"len=3"` // +validate:required
+}
+
+func Test_Markers(t *testing.T) {
+	for v, tag := range map[Markers]string{
+		{Name: "name", Code: "abc"}: "",
+		{Code: "abc"}:               "required",
+		{Name: "name"}:              "required",
+		{Name: "name", Code: "ab"}:  "len",
+	} {
+		got := ""
+		var fe *validation.FieldError
+		if err := v.Validate(); errors.As(err, &fe) {
+			got = fe.Tag
+		}
+
+		if got != tag {
+			t.Errorf("validating %+v, expected error tag %q, got %q", v, tag, got)
+		}
+	}
+}
+
+//validator:alias short=max=3
+//validator:alias code=required,len=3
+
+var _ Validator = Aliases{}
+
+type Aliases struct {
+	Name string `validate:"short"`
+	Code string `validate:"code"`
+	Nick string `validate:"len=0|short"`
+}
+
+func Test_Aliases(t *testing.T) {
+	for v, tags := range map[Aliases][2]string{
+		{Name: "abc", Code: "abc"}:               {},
+		{Name: "abcd", Code: "abc"}:              {"short", "max"},
+		{Name: "abc"}:                            {"code", "required"},
+		{Name: "abc", Code: "ab"}:                {"code", "len"},
+		{Name: "abc", Code: "abc", Nick: "abcd"}: {"len|short", "len|max"},
+	} {
+		var got [2]string
+		var fe *validation.FieldError
+		if err := v.Validate(); errors.As(err, &fe) {
+			got = [2]string{fe.Tag, fe.ActualTag}
+		}
+
+		if got != tags {
+			t.Errorf("validating %+v, expected error tag and actual tag %q, got %q", v, tags, got)
+		}
+	}
+}
+
+var (
+	_ Validator = Page[Required]{}
+	_ Validator = Range[string]{}
+	_ Validator = Count[int]{}
+)
+
+// Page validates the items with their Validate method.
+type Page[T Validator] struct {
+	Items []T `validate:"max=2"`
+	First *T
+}
+
+type Range[T cmp.Ordered] struct {
+	Min T `validate:"required"`
+	Max T `validate:"gte=Min"`
+}
+
+type Count[T ~int | ~int64] struct {
+	N T `validate:"min=1,max=10"`
+}
+
+func Test_Generics(t *testing.T) {
+	valid := NewValidRequired()
+	for name, c := range map[string]struct {
+		v   Validator
+		tag string
+	}{
+		"page":              {v: Page[Required]{Items: []Required{valid}, First: &valid}},
+		"page max":          {v: Page[Required]{Items: []Required{valid, valid, valid}}, tag: "max"},
+		"page nested item":  {v: Page[Required]{Items: []Required{valid, {}}}, tag: "required"},
+		"page nested first": {v: Page[Required]{First: &Required{}}, tag: "required"},
+		"range":             {v: Range[string]{Min: "a", Max: "b"}},
+		"range required":    {v: Range[int]{Max: 1}, tag: "required"},
+		"range gte":         {v: Range[float64]{Min: 2, Max: 1.5}, tag: "gte"},
+		"count":             {v: Count[int64]{N: 10}},
+		"count min":         {v: Count[int]{}, tag: "min"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			got := ""
+			var fe *validation.FieldError
+			if err := c.v.Validate(); errors.As(err, &fe) {
+				got = fe.Tag
+			}
+
+			if got != c.tag {
+				t.Errorf("expected error tag %q, got %q", c.tag, got)
+			}
+		})
+	}
+}
+
+var (
+	_ Validator = &Account{}
+	_ Validator = &Password{}
+)
+
+// Account is validated with the pointer receiver, which does not copy the struct.
+//
+//validator:receiver pointer
+type Account struct {
+	Email string `validate:"required,max=254"`
+	Notes [16]string
+}
+
+// Password wraps the generated validate in its own Validate. The method Validate has the pointer receiver,
+// so the generated one has the pointer receiver too.
+//
+//validator:unexported
+type Password struct {
+	Value string `validate:"min=8"`
+}
+
+func (p *Password) Validate() error {
+	if err := p.validate(); err != nil {
+		return fmt.Errorf("password: %w", err)
+	}
+
+	return nil
+}
+
+func Test_Receivers(t *testing.T) {
+	for name, c := range map[string]struct {
+		v   Validator
+		tag string
+	}{
+		"account":       {v: &Account{Email: "user@example.com"}},
+		"account email": {v: &Account{}, tag: "required"},
+		"password":      {v: &Password{Value: "12345678"}},
+		"password min":  {v: &Password{Value: "1234"}, tag: "min"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			got := ""
+			var fe *validation.FieldError
+			if err := c.v.Validate(); errors.As(err, &fe) {
+				got = fe.Tag
+			}
+
+			if got != c.tag {
+				t.Errorf("expected error tag %q, got %q", c.tag, got)
+			}
+		})
+	}
+}
+
+var _ Validator = &Search{}
+
+// Search is normalized with the modifiers of the mod tag before the validation.
+type Search struct {
+	Query string  `json:"query" mod:"trim,lcase" validate:"required"`
+	Email *string `json:"email" mod:"trim,lcase"`
+	Size  int     `json:"size" mod:"default=20" validate:"max=100"`
+	Sort  string  `json:"sort" mod:"rtrim,default=name"`
+}
+
+func Test_Normalize(t *testing.T) {
+	email := " User@Example.COM "
+	s := Search{Query: "  Go Generate\t", Email: &email, Sort: " "}
+	if err := s.NormalizeAndValidate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := Search{Query: "go generate", Email: &email, Size: 20, Sort: "name"}
+	if !reflect.DeepEqual(expected, s) || email != "user@example.com" {
+		t.Errorf("expected %+v with email %q, got: %+v with email %q", expected, "user@example.com", s, email)
+	}
+
+	blank := Search{Query: " ", Size: 101}
+	var fe *validation.FieldError
+	if err := blank.NormalizeAndValidate(); !errors.As(err, &fe) || fe.Tag != "required" {
+		t.Errorf("expected required error of the trimmed query, got: %v", err)
+	}
+
+	if blank.Email != nil {
+		t.Errorf("expected nil email to stay nil, got: %v", blank.Email)
+	}
+}
+
+var _ Validator = Formats{}
+
+type Formats struct {
+	Status string   `json:"status" validate:"oneof=new 'in progress'"`
+	Level  int8     `json:"level" validate:"oneof=1 2 -3"`
+	Code   string   `json:"code" validate:"regexp=^[A-Z]{2}[0-9]+$"`
+	Email  string   `json:"email" validate:"email"`
+	Name   string   `json:"name" validate:"required"`
+	Tags   []string `json:"tags" validate:"required,max=3,dive,required,max=4"`
+}
+
+func NewValidFormats() Formats {
+	return Formats{Status: "in progress", Level: -3, Code: "PL10", Email: "a@b.co", Name: "n", Tags: []string{"go"}}
+}
+
+// invalidFormats are the invalid instances of Formats by the case name.
+var invalidFormats = map[string]struct {
+	mut   func(f *Formats)
+	field string
+	err   string
+}{
+	"unknown status": {mut: func(f *Formats) { f.Status = "in" }, field: "status", err: `field "status" must be one of: new 'in progress'`},
+	"unknown level":  {mut: func(f *Formats) { f.Level = 3 }, field: "level", err: `field "level" must be one of: 1 2 -3`},
+	"code mismatch":  {mut: func(f *Formats) { f.Code = "P10" }, field: "code", err: `field "code" must match "^[A-Z]{2}[0-9]+$"`},
+	"display name":   {mut: func(f *Formats) { f.Email = "Ann <a@b.co>" }, field: "email", err: `field "email" must be an email address`},
+	"empty name":     {mut: func(f *Formats) { f.Name = "" }, field: "name", err: `field "name" is required`},
+	"nil tags":       {mut: func(f *Formats) { f.Tags = nil }, field: "tags", err: `field "tags" is required`},
+	"too many tags":  {mut: func(f *Formats) { f.Tags = []string{"a", "b", "c", "d"} }, field: "tags", err: `field "tags" must be at most 3`},
+	"empty tag":      {mut: func(f *Formats) { f.Tags = []string{"go", ""} }, field: "tags[1]", err: `field "tags" is required`},
+	"tag too long":   {mut: func(f *Formats) { f.Tags = []string{"golang"} }, field: "tags[0]", err: `field "tags" must be at most 4`},
+}
+
+func Test_Formats(t *testing.T) {
+	if err := NewValidFormats().Validate(); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+
+	for name, c := range invalidFormats {
+		t.Run(name, func(t *testing.T) {
+			v := NewValidFormats()
+			c.mut(&v)
+			var fe *validation.FieldError
+			if err := v.Validate(); !errors.As(err, &fe) || fe.Field != c.field || err.Error() != c.err {
+				t.Errorf("expected error %q of field %q, got %v", c.err, c.field, err)
+			}
+		})
+	}
+}
+
+// Test_JSONSchema checks that the JSON Schema of Formats accepts exactly the instances, which the generated code accepts.
+func Test_JSONSchema(t *testing.T) {
+	structs, err := internal.FindStructsInFile(token.NewFileSet(), "generated_test.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	idx := slices.IndexFunc(structs, func(s internal.Struct) bool { return s.Name == "Formats" })
+	schemas, err := internal.JSONSchemas(structs[idx : idx+1])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	instances := map[string]Formats{"valid": NewValidFormats()}
+	for name, c := range invalidFormats {
+		v := NewValidFormats()
+		c.mut(&v)
+		instances[name] = v
+	}
+
+	for name, v := range instances {
+		t.Run(name, func(t *testing.T) {
+			data, err := json.Marshal(v)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var instance any
+			if err := json.Unmarshal(data, &instance); err != nil {
+				t.Fatal(err)
+			}
+
+			if expected, got := v.Validate() == nil, schemaAccepts(schemas["Formats"], instance); expected != got {
+				t.Errorf("generated code accepts %s: %t, schema accepts it: %t", data, expected, got)
+			}
+		})
+	}
+}
+
+// schemaAccepts returns true, when the instance satisfies the keywords of the schema, which JSONSchemas emits.
+func schemaAccepts(s *internal.Schema, instance any) bool {
+	if s.Enum != nil && !slices.ContainsFunc(s.Enum, func(v any) bool { return fmt.Sprint(v) == fmt.Sprint(instance) }) {
+		return false
+	}
+
+	switch v := instance.(type) {
+	case string:
+		n := utf8.RuneCountInString(v)
+		return (s.MinLength == nil || n >= *s.MinLength) && (s.MaxLength == nil || n <= *s.MaxLength) &&
+			(s.Pattern == "" || regexp.MustCompile(s.Pattern).MatchString(v)) &&
+			(s.Format != "email" || emailFormat.MatchString(v))
+	case float64:
+		return (s.Minimum == nil || v >= *s.Minimum) && (s.Maximum == nil || v <= *s.Maximum)
+	case []any:
+		if (s.MinItems != nil && len(v) < *s.MinItems) || (s.MaxItems != nil && len(v) > *s.MaxItems) {
+			return false
+		}
+
+		return !slices.ContainsFunc(v, func(item any) bool { return !schemaAccepts(s.Items, item) })
+	case map[string]any:
+		for _, name := range s.Required {
+			if _, ok := v[name]; !ok {
+				return false
+			}
+		}
+
+		for name, prop := range s.Properties {
+			if value, ok := v[name]; ok && !schemaAccepts(prop, value) {
+				return false
+			}
+		}
+	case nil:
+		t, ok := s.Type.(string)
+		return !ok || t == "null"
+	}
+
+	return true
+}
+
+// emailFormat is the mailbox of the email format, the address without the display name.
+var emailFormat = regexp.MustCompile(`^[^\s@<>]+@[^\s@<>]+$`)
@@ -1,20 +1,252 @@
-package // File generated automatically by validator. DO NOT EDIT.
-main_test
+// Code generated by validator. DO NOT EDIT.
 
-import "errors"
+package main_test
+
+import (
+	"fmt"
+	"github.com/paluszkiewiczB/validator/validation"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Validate implements Validator.
+func (a *Account) Validate() error {
+	if len(a.Email) == 0 {
+		return &validation.FieldError{Struct: "Account", Field: "Email", StructField: "Email", Tag: "required", ActualTag: "required", Param: "", Value: a.Email, Message: "field \"Email\" is required"}
+	}
+	if utf8.RuneCountInString(a.Email) > 254 {
+		return &validation.FieldError{Struct: "Account", Field: "Email", StructField: "Email", Tag: "max", ActualTag: "max", Param: "254", Value: a.Email, Message: "field \"Email\" must be at most 254"}
+	}
+	return nil
+}
+
+// Validate implements Validator.
+func (a Aliases) Validate() error {
+	if utf8.RuneCountInString(a.Name) > 3 {
+		return &validation.FieldError{Struct: "Aliases", Field: "Name", StructField: "Name", Tag: "short", ActualTag: "max", Param: "3", Value: a.Name, Message: "field \"Name\" must be at most 3"}
+	}
+	if len(a.Code) == 0 {
+		return &validation.FieldError{Struct: "Aliases", Field: "Code", StructField: "Code", Tag: "code", ActualTag: "required", Param: "", Value: a.Code, Message: "field \"Code\" is required"}
+	}
+	if utf8.RuneCountInString(a.Code) != 3 {
+		return &validation.FieldError{Struct: "Aliases", Field: "Code", StructField: "Code", Tag: "code", ActualTag: "len", Param: "3", Value: a.Code, Message: "field \"Code\" must have length 3"}
+	}
+	if !(utf8.RuneCountInString(a.Nick) == 0 || utf8.RuneCountInString(a.Nick) <= 3) {
+		return &validation.FieldError{Struct: "Aliases", Field: "Nick", StructField: "Nick", Tag: "len|short", ActualTag: "len|max", Param: "", Value: a.Nick, Message: "field \"Nick\" must have length 0 or field \"Nick\" must be at most 3"}
+	}
+	return nil
+}
+
+// Validate implements Validator.
+func (a Alternatives) Validate() error {
+	if !(len(a.Primary) != 0 || a.Primary == a.Backup) {
+		return &validation.FieldError{Struct: "Alternatives", Field: "Primary", StructField: "Primary", Tag: "required|eqfield", ActualTag: "required|eqfield", Param: "", Value: a.Primary, Message: "field \"Primary\" is required or field \"Primary\" must be equal to \"Backup\""}
+	}
+	if !(float64(a.Score) >= float64(a.Min) || float64(a.Score) >= float64(a.Max)) {
+		return &validation.FieldError{Struct: "Alternatives", Field: "Score", StructField: "Score", Tag: "gte|gte", ActualTag: "gte|gte", Param: "", Value: a.Score, Message: fmt.Sprintf("Score is too low: %v", a.Score)}
+	}
+	return nil
+}
+
+// Validate implements Validator.
+func (c Count[T]) Validate() error {
+	if c.N < 1 {
+		return &validation.FieldError{Struct: "Count", Field: "N", StructField: "N", Tag: "min", ActualTag: "min", Param: "1", Value: c.N, Message: "field \"N\" must be at least 1"}
+	}
+	if c.N > 10 {
+		return &validation.FieldError{Struct: "Count", Field: "N", StructField: "N", Tag: "max", ActualTag: "max", Param: "10", Value: c.N, Message: "field \"N\" must be at most 10"}
+	}
+	return nil
+}
 
 // Validate implements Validator.
 func (e Eqfield) Validate() error {
 	if e.Field2 != e.Field1 {
-		return errors.New("field \"Field2\" must be equal to \"Field1\"")
+		return &validation.FieldError{Struct: "Eqfield", Field: "Field2", StructField: "Field2", Tag: "eqfield", ActualTag: "eqfield", Param: "Field1", Value: e.Field2, Message: "field \"Field2\" must be equal to \"Field1\""}
 	}
 	return nil
 }
 
+// Validate implements Validator.
+func (f Formats) Validate() error {
+	if !(f.Status == "new" || f.Status == "in progress") {
+		return &validation.FieldError{Struct: "Formats", Field: "status", StructField: "Status", Tag: "oneof", ActualTag: "oneof", Param: "new 'in progress'", Value: f.Status, Message: "field \"status\" must be one of: new 'in progress'"}
+	}
+	if !(f.Level == 1 || f.Level == 2 || f.Level == -3) {
+		return &validation.FieldError{Struct: "Formats", Field: "level", StructField: "Level", Tag: "oneof", ActualTag: "oneof", Param: "1 2 -3", Value: f.Level, Message: "field \"level\" must be one of: 1 2 -3"}
+	}
+	if !(validation.MatchString("^[A-Z]{2}[0-9]+$", f.Code)) {
+		return &validation.FieldError{Struct: "Formats", Field: "code", StructField: "Code", Tag: "regexp", ActualTag: "regexp", Param: "^[A-Z]{2}[0-9]+$", Value: f.Code, Message: "field \"code\" must match \"^[A-Z]{2}[0-9]+$\""}
+	}
+	if !(validation.IsEmail(f.Email)) {
+		return &validation.FieldError{Struct: "Formats", Field: "email", StructField: "Email", Tag: "email", ActualTag: "email", Param: "", Value: f.Email, Message: "field \"email\" must be an email address"}
+	}
+	if len(f.Name) == 0 {
+		return &validation.FieldError{Struct: "Formats", Field: "name", StructField: "Name", Tag: "required", ActualTag: "required", Param: "", Value: f.Name, Message: "field \"name\" is required"}
+	}
+	if len(f.Tags) == 0 {
+		return &validation.FieldError{Struct: "Formats", Field: "tags", StructField: "Tags", Tag: "required", ActualTag: "required", Param: "", Value: f.Tags, Message: "field \"tags\" is required"}
+	}
+	if len(f.Tags) > 3 {
+		return &validation.FieldError{Struct: "Formats", Field: "tags", StructField: "Tags", Tag: "max", ActualTag: "max", Param: "3", Value: f.Tags, Message: "field \"tags\" must be at most 3"}
+	}
+	for idx, elem := range f.Tags {
+		if len(elem) == 0 {
+			return &validation.FieldError{Struct: "Formats", Field: fmt.Sprintf("tags[%d]", idx), StructField: fmt.Sprintf("Tags[%d]", idx), Tag: "required", ActualTag: "required", Param: "", Value: elem, Message: "field \"tags\" is required"}
+		}
+		if utf8.RuneCountInString(elem) > 4 {
+			return &validation.FieldError{Struct: "Formats", Field: fmt.Sprintf("tags[%d]", idx), StructField: fmt.Sprintf("Tags[%d]", idx), Tag: "max", ActualTag: "max", Param: "4", Value: elem, Message: "field \"tags\" must be at most 4"}
+		}
+	}
+	return nil
+}
+
+// Validate implements Validator.
+func (g Groups) Validate() error {
+	if len(g.Password) == 0 {
+		return &validation.FieldError{Struct: "Groups", Field: "Password", StructField: "Password", Tag: "required", ActualTag: "required", Param: "", Value: g.Password, Message: "field \"Password\" is required"}
+	}
+	return nil
+}
+
+// ValidateGroup validates the fields with the validations of the group.
+func (g Groups) ValidateGroup(group string) error {
+	switch group {
+	case "":
+		return g.Validate()
+	case "create":
+		if len(g.Password) == 0 {
+			return &validation.FieldError{Struct: "Groups", Field: "Password", StructField: "Password", Tag: "required", ActualTag: "required", Param: "", Value: g.Password, Message: "field \"Password\" is required"}
+		}
+		if g.Password != g.Repeated {
+			return &validation.FieldError{Struct: "Groups", Field: "Password", StructField: "Password", Tag: "eqfield", ActualTag: "eqfield", Param: "Repeated", Value: g.Password, Message: "field \"Password\" must be equal to \"Repeated\""}
+		}
+		return nil
+	case "update":
+		if len(g.Password) == 0 {
+			return &validation.FieldError{Struct: "Groups", Field: "Password", StructField: "Password", Tag: "required", ActualTag: "required", Param: "", Value: g.Password, Message: "field \"Password\" is required"}
+		}
+		if g.Email == nil {
+			return &validation.FieldError{Struct: "Groups", Field: "email", StructField: "Email", Tag: "required", ActualTag: "required", Param: "", Value: g.Email, Message: "field \"email\" is required"}
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown validation group: %q", group)
+}
+
 // Validate implements Validator.
 func (g Gte) Validate() error {
-	if val, than := float64(g.Two), float64(g.One); val < than {
-		return errors.New("field \"Two\" must greater or equal than \"One\"")
+	if !(float64(g.Two) >= float64(g.One)) {
+		return &validation.FieldError{Struct: "Gte", Field: "Two", StructField: "Two", Tag: "gte", ActualTag: "gte", Param: "One", Value: g.Two, Message: "field \"Two\" must be greater than or equal to \"One\""}
+	}
+	return nil
+}
+
+// Validate implements Validator.
+func (l Length) Validate() error {
+	if utf8.RuneCountInString(l.Name) < 2 {
+		return &validation.FieldError{Struct: "Length", Field: "Name", StructField: "Name", Tag: "min", ActualTag: "min", Param: "2", Value: l.Name, Message: "field \"Name\" must be at least 2"}
+	}
+	if utf8.RuneCountInString(l.Name) > 4 {
+		return &validation.FieldError{Struct: "Length", Field: "Name", StructField: "Name", Tag: "max", ActualTag: "max", Param: "4", Value: l.Name, Message: "field \"Name\" must be at most 4"}
+	}
+	if utf8.RuneCountInString(l.Code) != 3 {
+		return &validation.FieldError{Struct: "Length", Field: "Code", StructField: "Code", Tag: "len", ActualTag: "len", Param: "3", Value: l.Code, Message: "field \"Code\" must have length 3"}
+	}
+	if len(l.Tags) > 2 {
+		return &validation.FieldError{Struct: "Length", Field: "Tags", StructField: "Tags", Tag: "max", ActualTag: "max", Param: "2", Value: l.Tags, Message: "field \"Tags\" must be at most 2"}
+	}
+	if len(l.Labels) < 1 {
+		return &validation.FieldError{Struct: "Length", Field: "Labels", StructField: "Labels", Tag: "min", ActualTag: "min", Param: "1", Value: l.Labels, Message: "field \"Labels\" must be at least 1"}
+	}
+	if l.Count < 1 {
+		return &validation.FieldError{Struct: "Length", Field: "Count", StructField: "Count", Tag: "min", ActualTag: "min", Param: "1", Value: l.Count, Message: "field \"Count\" must be at least 1"}
+	}
+	if l.Count > 10 {
+		return &validation.FieldError{Struct: "Length", Field: "Count", StructField: "Count", Tag: "max", ActualTag: "max", Param: "10", Value: l.Count, Message: "field \"Count\" must be at most 10"}
+	}
+	if !(l.Ratio <= 0.5) {
+		return &validation.FieldError{Struct: "Length", Field: "Ratio", StructField: "Ratio", Tag: "max", ActualTag: "max", Param: "0.5", Value: l.Ratio, Message: "field \"Ratio\" must be at most 0.5"}
+	}
+	return nil
+}
+
+// Validate implements Validator.
+func (m Markers) Validate() error {
+	if len(m.Name) == 0 {
+		return &validation.FieldError{Struct: "Markers", Field: "Name", StructField: "Name", Tag: "required", ActualTag: "required", Param: "", Value: m.Name, Message: "field \"Name\" is required"}
+	}
+	if len(m.Code) == 0 {
+		return &validation.FieldError{Struct: "Markers", Field: "Code", StructField: "Code", Tag: "required", ActualTag: "required", Param: "", Value: m.Code, Message: "field \"Code\" is required"}
+	}
+	if utf8.RuneCountInString(m.Code) != 3 {
+		return &validation.FieldError{Struct: "Markers", Field: "Code", StructField: "Code", Tag: "len", ActualTag: "len", Param: "3", Value: m.Code, Message: "field \"Code\" must have length 3"}
+	}
+	return nil
+}
+
+// Validate implements Validator.
+func (m Messages) Validate() error {
+	if len(m.Password) == 0 {
+		return &validation.FieldError{Struct: "Messages", Field: "Password", StructField: "Password", Tag: "required", ActualTag: "required", Param: "", Value: m.Password, Message: "Password must be provided"}
+	}
+	if m.Repeated != m.Password {
+		return &validation.FieldError{Struct: "Messages", Field: "Repeated", StructField: "Repeated", Tag: "eqfield", ActualTag: "eqfield", Param: "Password", Value: m.Repeated, Message: fmt.Sprintf("Repeated must repeat Password, got: %v", m.Repeated)}
+	}
+	return nil
+}
+
+// Validate implements Validator.
+func (n Names) Validate() error {
+	if n.StringPointer == nil {
+		return &validation.FieldError{Struct: "Names", Field: "string_pointer", StructField: "StringPointer", Tag: "required", ActualTag: "required", Param: "", Value: n.StringPointer, Message: "field \"string_pointer\" is required"}
+	}
+	if n.Ignored == nil {
+		return &validation.FieldError{Struct: "Names", Field: "Ignored", StructField: "Ignored", Tag: "required", ActualTag: "required", Param: "", Value: n.Ignored, Message: "field \"Ignored\" is required"}
+	}
+	if n.Dash == nil {
+		return &validation.FieldError{Struct: "Names", Field: "-", StructField: "Dash", Tag: "required", ActualTag: "required", Param: "", Value: n.Dash, Message: "field \"-\" is required"}
+	}
+	if n.Unnamed == nil {
+		return &validation.FieldError{Struct: "Names", Field: "Unnamed", StructField: "Unnamed", Tag: "required", ActualTag: "required", Param: "", Value: n.Unnamed, Message: "field \"Unnamed\" is required"}
+	}
+	return nil
+}
+
+// Validate implements Validator.
+func (p Page[T]) Validate() error {
+	if len(p.Items) > 2 {
+		return &validation.FieldError{Struct: "Page", Field: "Items", StructField: "Items", Tag: "max", ActualTag: "max", Param: "2", Value: p.Items, Message: "field \"Items\" must be at most 2"}
+	}
+	for _, elem := range p.Items {
+		if err := elem.Validate(); err != nil {
+			return err
+		}
+	}
+	if p.First != nil {
+		if err := (*p.First).Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validate validates the struct, the package can wrap it in its own Validate.
+func (p *Password) validate() error {
+	if utf8.RuneCountInString(p.Value) < 8 {
+		return &validation.FieldError{Struct: "Password", Field: "Value", StructField: "Value", Tag: "min", ActualTag: "min", Param: "8", Value: p.Value, Message: "field \"Value\" must be at least 8"}
+	}
+	return nil
+}
+
+// Validate implements Validator.
+func (r Range[T]) Validate() error {
+	if r.Min == *new(T) {
+		return &validation.FieldError{Struct: "Range", Field: "Min", StructField: "Min", Tag: "required", ActualTag: "required", Param: "", Value: r.Min, Message: "field \"Min\" is required"}
+	}
+	if !(r.Max >= r.Min) {
+		return &validation.FieldError{Struct: "Range", Field: "Max", StructField: "Max", Tag: "gte", ActualTag: "gte", Param: "Min", Value: r.Max, Message: "field \"Max\" must be greater than or equal to \"Min\""}
 	}
 	return nil
 }
@@ -22,16 +254,50 @@ func (g Gte) Validate() error {
 // Validate implements Validator.
 func (r Required) Validate() error {
 	if len(r.String) == 0 {
-		return errors.New("field \"String\" is required")
+		return &validation.FieldError{Struct: "Required", Field: "String", StructField: "String", Tag: "required", ActualTag: "required", Param: "", Value: r.String, Message: "field \"String\" is required"}
 	}
 	if r.StringPointer == nil {
-		return errors.New("field \"StringPointer\" is required")
+		return &validation.FieldError{Struct: "Required", Field: "StringPointer", StructField: "StringPointer", Tag: "required", ActualTag: "required", Param: "", Value: r.StringPointer, Message: "field \"StringPointer\" is required"}
 	}
 	if len(r.Slice) == 0 {
-		return errors.New("field \"Slice\" is required")
+		return &validation.FieldError{Struct: "Required", Field: "Slice", StructField: "Slice", Tag: "required", ActualTag: "required", Param: "", Value: r.Slice, Message: "field \"Slice\" is required"}
 	}
 	if len(r.Map) == 0 {
-		return errors.New("field \"Map\" is required")
+		return &validation.FieldError{Struct: "Required", Field: "Map", StructField: "Map", Tag: "required", ActualTag: "required", Param: "", Value: r.Map, Message: "field \"Map\" is required"}
 	}
 	return nil
 }
+
+// Validate implements Validator.
+func (s *Search) Validate() error {
+	if len(s.Query) == 0 {
+		return &validation.FieldError{Struct: "Search", Field: "query", StructField: "Query", Tag: "required", ActualTag: "required", Param: "", Value: s.Query, Message: "field \"query\" is required"}
+	}
+	if s.Size > 100 {
+		return &validation.FieldError{Struct: "Search", Field: "size", StructField: "Size", Tag: "max", ActualTag: "max", Param: "100", Value: s.Size, Message: "field \"size\" must be at most 100"}
+	}
+	return nil
+}
+
+// Normalize modifies the fields with the modifiers of the mod tag.
+func (s *Search) Normalize() {
+	s.Query = strings.TrimSpace(s.Query)
+	s.Query = strings.ToLower(s.Query)
+	if s.Email != nil {
+		*s.Email = strings.TrimSpace(*s.Email)
+		*s.Email = strings.ToLower(*s.Email)
+	}
+	if s.Size == 0 {
+		s.Size = 20
+	}
+	s.Sort = strings.TrimRightFunc(s.Sort, unicode.IsSpace)
+	if s.Sort == "" {
+		s.Sort = "name"
+	}
+}
+
+// NormalizeAndValidate modifies the fields and validates the struct.
+func (s *Search) NormalizeAndValidate() error {
+	s.Normalize()
+	return s.Validate()
+}
@@ -1,8 +1,13 @@
 module github.com/paluszkiewiczB/validator
 
-go 1.22.1
+go 1.25.0
 
 require (
+	github.com/emicklei/proto v1.14.2
 	golang.org/x/exp v0.0.0-20240416160154-fe59bbe5cc7f
-	golang.org/x/tools v0.20.0
+	golang.org/x/mod v0.36.0
+	golang.org/x/tools v0.45.0
+	gopkg.in/yaml.v3 v3.0.1
 )
+
+require golang.org/x/sync v0.20.0 // indirect
@@ -0,0 +1,63 @@
+package internal
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"strings"
+)
+
+// AliasDirective is the prefix of the comment declaring the alias in the files of the package, like the aliases
+// of the ConfigFile:
+//
+//	//validator:alias iscolor=hexcolor|rgb|rgba
+const AliasDirective = "//validator:alias "
+
+// PackageAliases returns the Aliases declared with the AliasDirective in the files of the package.
+// The alias can be declared more than once, but only with the same rules.
+func PackageAliases(fset *token.FileSet, files ...*ast.File) (map[string]string, error) {
+	aliases := map[string]string{}
+	declared := map[string]token.Position{}
+	for _, f := range files {
+		for _, group := range f.Comments {
+			for _, c := range group.List {
+				decl, ok := strings.CutPrefix(c.Text, AliasDirective)
+				if !ok {
+					continue
+				}
+
+				pos := fset.Position(c.Pos())
+				name, rules, _ := strings.Cut(strings.TrimSpace(decl), "=")
+				if err := validateAlias(name, rules); err != nil {
+					return nil, fmt.Errorf("%s: %w", pos, err)
+				}
+
+				if prev, ok := aliases[name]; ok && prev != rules {
+					return nil, fmt.Errorf("%s: alias %q redeclared with %q, declared with %q at %s", pos, name, rules, prev, declared[name])
+				}
+
+				aliases[name], declared[name] = rules, pos
+			}
+		}
+	}
+
+	return aliases, nil
+}
+
+// validateAlias returns the error, when the name of the alias is not a valid rule name, it shadows the rule
+// or it has no rules.
+func validateAlias(name, rules string) error {
+	if name == "" || strings.ContainsAny(name, " ,;:|=") {
+		return fmt.Errorf("invalid alias name %q", name)
+	}
+
+	if GeneratorFor(name) != nil {
+		return fmt.Errorf("alias %q shadows the rule of the same name", name)
+	}
+
+	if rules == "" {
+		return fmt.Errorf("alias %q has no rules", name)
+	}
+
+	return nil
+}
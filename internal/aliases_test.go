@@ -0,0 +1,50 @@
+package internal_test
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/paluszkiewiczB/validator/internal"
+)
+
+func Test_PackageAliases(t *testing.T) {
+	fset := token.NewFileSet()
+	parse := func(src string) *ast.File {
+		f, err := parser.ParseFile(fset, "aliases.go", src, parser.ParseComments)
+		if err != nil {
+			t.Fatalf("parsing source: %v", err)
+		}
+
+		return f
+	}
+
+	a := parse("package p\n\n//validator:alias iscolor=hexcolor|rgb|rgba\n//validator:alias name=required,max=10\n")
+	b := parse("package p\n\n// not a directive: validator:alias other=required\n//validator:alias iscolor=hexcolor|rgb|rgba\n")
+	aliases, err := internal.PackageAliases(fset, a, b)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string]string{"iscolor": "hexcolor|rgb|rgba", "name": "required,max=10"}
+	if !reflect.DeepEqual(expected, aliases) {
+		t.Errorf("expected aliases %v, got: %v", expected, aliases)
+	}
+
+	for src, msg := range map[string]string{
+		"package p\n//validator:alias iscolor=rgb\n":  `aliases.go:2:1: alias "iscolor" redeclared with "rgb", declared with "hexcolor|rgb|rgba" at aliases.go:3:1`,
+		"package p\n//validator:alias max=len=1\n":    `aliases.go:2:1: alias "max" shadows the rule of the same name`,
+		"package p\n//validator:alias iscolor\n":      `aliases.go:2:1: alias "iscolor" has no rules`,
+		"package p\n//validator:alias is color=rgb\n": `aliases.go:2:1: invalid alias name "is color"`,
+	} {
+		t.Run(src, func(t *testing.T) {
+			_, err := internal.PackageAliases(fset, a, parse(src))
+			if err == nil || !strings.Contains(err.Error(), msg) {
+				t.Errorf("expected error %q, got: %v", msg, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package internal
+
+import "golang.org/x/exp/maps"
+
+// BuildCatalog returns the translation catalog with the message templates of all the validations of the structs,
+// in the format of validation.Catalog. Templates of the single rules are stored by the rule ID, e.g. 'max', and keep
+// the placeholder of the parameter. Templates overridden by the field and templates of the alternatives are qualified
+// with the struct and field name, e.g. 'User.Password.required' or 'User.Color.rgb|rgba', because the parameters
+// of the alternatives are already resolved and differ between the fields.
+func BuildCatalog(structs []Struct) Messages {
+	catalog := Messages{}
+	for _, str := range structs {
+		for _, field := range str.Fields {
+			for _, vals := range append([]Validations{field.Validations}, maps.Values(field.Groups)...) {
+				for _, alts := range vals {
+					key := alts.Tag()
+					if len(alts) == 1 {
+						catalog[alts[0].Name] = catalogMessage(alts, str, Field{})
+					}
+
+					if len(alts) != 1 || field.Messages[key] != "" || field.Messages[""] != "" {
+						catalog[str.Name+"."+field.Name+"."+key] = catalogMessage(alts, str, field)
+					}
+				}
+			}
+		}
+	}
+
+	return catalog
+}
+
+// catalogMessage returns the template of the alternatives. Template of a single rule keeps PlaceholderParam,
+// because validation.FieldError has the Param of the rule.
+func catalogMessage(alts Alternatives, str Struct, field Field) string {
+	if len(alts) == 1 {
+		return Message(alts[0].Name, str, field)
+	}
+
+	return alternativesMessage(alts, str, field)
+}
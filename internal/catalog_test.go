@@ -0,0 +1,43 @@
+package internal_test
+
+import (
+	"go/parser"
+	"go/token"
+	"reflect"
+	"testing"
+
+	"github.com/paluszkiewiczB/validator/internal"
+)
+
+func Test_BuildCatalog(t *testing.T) {
+	internal.Log = newTestLog(t)
+	internal.Aliases = map[string]string{"short": "max=3"}
+	t.Cleanup(func() { internal.Aliases = map[string]string{} })
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "example.go", "package example\n\n"+
+		"type Range struct {\n"+
+		"\tMin int `validate:\"min=1|len=0\"`\n"+
+		"\tMax int `validate:\"min=10|len=0\"`\n"+
+		"\tCode string `validate:\"short\" msg:\"{field} is too long\"`\n"+
+		"}\n", parser.AllErrors)
+	if err != nil {
+		t.Fatalf("parsing source: %v", err)
+	}
+
+	structs, err := internal.FindStructs(fset, f)
+	if err != nil {
+		t.Fatalf("finding structs: %v", err)
+	}
+
+	expected := internal.Messages{
+		"max":               internal.DefaultMessages["max"],
+		"Range.Min.min|len": `field "{field}" must be at least 1 or field "{field}" must have length 0`,
+		"Range.Max.min|len": `field "{field}" must be at least 10 or field "{field}" must have length 0`,
+		"Range.Code.short":  "{field} is too long",
+	}
+
+	if got := internal.BuildCatalog(structs); !reflect.DeepEqual(expected, got) {
+		t.Errorf("expected catalog %#v, got: %#v", expected, got)
+	}
+}
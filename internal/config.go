@@ -0,0 +1,314 @@
+package internal
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFile is the name of the project configuration file, discovered in the directory of the input file and its parents.
+const ConfigFile = ".validator.yaml"
+
+// GoNames is the NameTag of the Config reporting the Go names of the fields.
+const GoNames = "go"
+
+// OutPlaceholder is replaced in the Out of the Config with the name of the input file without the extension.
+const OutPlaceholder = "{in}"
+
+// ErrorStyle decides, which errors are returned by the generated methods.
+type ErrorStyle string
+
+const (
+	// FailFast returns the first violated rule as *validation.FieldError.
+	FailFast ErrorStyle = "fail-fast"
+	// CollectAll returns the first violated rule of every field as validation.FieldErrors.
+	CollectAll ErrorStyle = "collect-all"
+)
+
+// Errors is the ErrorStyle of the generated methods.
+var Errors = FailFast
+
+// Config is the project configuration of the generator, read from the ConfigFile:
+//
+//	tag-keys: [binding, validate]
+//	errors: collect-all
+//	name-tag: json
+//	messages:
+//	  required: '{field} is required'
+//	aliases:
+//	  iscolor: hexcolor|rgb|rgba
+//	out: '{in}_validation.go'
+//	receiver: pointer
+//	packages:
+//	  internal/legacy:
+//	    name-tag: go
+//
+// Packages are the overrides of the settings for the directories, relative to the directory of the ConfigFile.
+// Booleans are pointers, so the override can turn off the setting turned on by the ConfigFile.
+// The flags of the generator take precedence over the Config.
+type Config struct {
+	TagKeys  []string          `yaml:"tag-keys,omitempty"`
+	Errors   ErrorStyle        `yaml:"errors,omitempty"`
+	NameTag  string            `yaml:"name-tag,omitempty"`
+	Messages map[string]string `yaml:"messages,omitempty"`
+	Aliases  map[string]string `yaml:"aliases,omitempty"`
+	Out      string            `yaml:"out,omitempty"`
+	OutPkg   string            `yaml:"outpkg,omitempty"`
+	Receiver ReceiverKind      `yaml:"receiver,omitempty"`
+	Unexport *bool             `yaml:"unexported,omitempty"`
+	Debug    *bool             `yaml:"debug,omitempty"`
+	Packages map[string]Config `yaml:"packages,omitempty"`
+}
+
+// DefaultConfig returns the Config of the defaults of the flags.
+func DefaultConfig() Config {
+	return Config{
+		TagKeys:  []string{DefaultTagKey},
+		Errors:   FailFast,
+		NameTag:  GoNames,
+		Out:      "generated.go",
+		OutPkg:   "main",
+		Receiver: AutoReceiver,
+		Unexport: new(bool),
+		Debug:    new(bool),
+	}
+}
+
+// FindConfig returns the effective Config of the directory: DefaultConfig merged with the nearest ConfigFile
+// in the directory or its parents and its overrides of the directory. It returns the path of the ConfigFile as well,
+// which is empty, when there is none.
+func FindConfig(dir string) (Config, string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return Config{}, "", fmt.Errorf("finding config: %w", err)
+	}
+
+	for current := abs; ; current = filepath.Dir(current) {
+		file := filepath.Join(current, ConfigFile)
+		content, err := os.ReadFile(file)
+		if err == nil {
+			cfg, err := ParseConfig(content)
+			if err != nil {
+				return Config{}, "", fmt.Errorf("%s: %w", file, err)
+			}
+
+			rel, err := filepath.Rel(current, abs)
+			if err != nil {
+				return Config{}, "", fmt.Errorf("finding config: %w", err)
+			}
+
+			return DefaultConfig().merge(cfg).merge(cfg.Packages[filepath.ToSlash(rel)]), file, nil
+		}
+
+		if !errors.Is(err, fs.ErrNotExist) {
+			return Config{}, "", fmt.Errorf("reading config: %w", err)
+		}
+
+		if filepath.Dir(current) == current {
+			return DefaultConfig(), "", nil
+		}
+	}
+}
+
+// ParseConfig parses and validates the content of the ConfigFile. Unknown settings are rejected.
+func ParseConfig(content []byte) (Config, error) {
+	cfg := Config{}
+	dec := yaml.NewDecoder(bytes.NewReader(content))
+	dec.KnownFields(true)
+	if err := dec.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+		return Config{}, fmt.Errorf("parsing config: %w", err)
+	}
+
+	if err := cfg.validate(); err != nil {
+		return Config{}, err
+	}
+
+	packages := make(map[string]Config, len(cfg.Packages))
+	for dir, override := range cfg.Packages {
+		if len(override.Packages) != 0 {
+			return Config{}, fmt.Errorf("package %q: overrides can not declare packages", dir)
+		}
+
+		if err := override.validate(); err != nil {
+			return Config{}, fmt.Errorf("package %q: %w", dir, err)
+		}
+
+		packages[path.Clean(dir)] = override
+	}
+
+	cfg.Packages = packages
+	return cfg, nil
+}
+
+func (c Config) validate() error {
+	if c.Errors != "" && c.Errors != FailFast && c.Errors != CollectAll {
+		return fmt.Errorf("unknown errors %q, expected %q or %q", c.Errors, FailFast, CollectAll)
+	}
+
+	for _, key := range c.TagKeys {
+		if key == "" || strings.ContainsAny(key, " :\"") {
+			return fmt.Errorf("invalid tag key %q", key)
+		}
+	}
+
+	for name, rules := range c.Aliases {
+		if err := validateAlias(name, rules); err != nil {
+			return err
+		}
+	}
+
+	if c.Receiver != "" {
+		if _, err := ParseReceiverKind(string(c.Receiver)); err != nil {
+			return err
+		}
+	}
+
+	if c.Out != "" && filepath.Base(c.Out) != c.Out {
+		return fmt.Errorf("out %q must be the name of the file in the directory of the input file", c.Out)
+	}
+
+	return nil
+}
+
+// merge returns the Config with the settings of the override, maps are merged by the keys.
+func (c Config) merge(o Config) Config {
+	if len(o.TagKeys) != 0 {
+		c.TagKeys = o.TagKeys
+	}
+
+	if o.Errors != "" {
+		c.Errors = o.Errors
+	}
+
+	if o.NameTag != "" {
+		c.NameTag = o.NameTag
+	}
+
+	if o.Out != "" {
+		c.Out = o.Out
+	}
+
+	if o.OutPkg != "" {
+		c.OutPkg = o.OutPkg
+	}
+
+	if o.Receiver != "" {
+		c.Receiver = o.Receiver
+	}
+
+	if o.Unexport != nil {
+		c.Unexport = o.Unexport
+	}
+
+	if o.Debug != nil {
+		c.Debug = o.Debug
+	}
+
+	c.Messages = mergeMaps(c.Messages, o.Messages)
+	c.Aliases = mergeMaps(c.Aliases, o.Aliases)
+	c.Packages = nil
+	return c
+}
+
+func mergeMaps(a, b map[string]string) map[string]string {
+	if len(a) == 0 && len(b) == 0 {
+		return nil
+	}
+
+	out := maps.Clone(a)
+	if out == nil {
+		out = map[string]string{}
+	}
+
+	maps.Copy(out, b)
+	return out
+}
+
+// WithAliases returns the Config with the aliases merged into its Aliases, e.g. the aliases declared in the code.
+func (c Config) WithAliases(aliases map[string]string) Config {
+	c.Aliases = mergeMaps(c.Aliases, aliases)
+	return c
+}
+
+// OutFile returns the path of the output file of the input file, see OutPlaceholder.
+// The output file is placed in the directory of the input file, like the config file is found from it.
+func (c Config) OutFile(in string) string {
+	name := strings.TrimSuffix(filepath.Base(in), filepath.Ext(in))
+	return filepath.Join(filepath.Dir(in), strings.ReplaceAll(c.Out, OutPlaceholder, name))
+}
+
+// Settings are the settings of the generator, which FindStructsWith resolves into the structs,
+// so the generation of the structs does not read the globals, e.g. in the analyzers of the packages run in parallel.
+type Settings struct {
+	Syntax
+	// NameTag is the tag of the names reported in the errors, see NameTag.
+	NameTag string
+	// Templates override DefaultMessages, see Templates.
+	Templates Messages
+	// Errors is the ErrorStyle of the generated methods, see Errors.
+	Errors ErrorStyle
+	// Receivers is the ReceiverKind of the structs without the ReceiverDirective, see Receivers.
+	Receivers ReceiverKind
+	// Unexported is true, when the generated methods of all the structs are unexported, see Unexported.
+	Unexported bool
+}
+
+// CurrentSettings returns the Settings of the globals TagKeys, Aliases, NameTag, Templates, Errors, Receivers
+// and Unexported.
+func CurrentSettings() Settings {
+	return Settings{
+		Syntax:     CurrentSyntax(),
+		NameTag:    NameTag,
+		Templates:  Templates,
+		Errors:     Errors,
+		Receivers:  Receivers,
+		Unexported: Unexported,
+	}
+}
+
+// Settings returns the Settings of the Config.
+func (c Config) Settings() Settings {
+	s := Settings{
+		Syntax:     Syntax{TagKeys: c.TagKeys, Aliases: c.Aliases},
+		NameTag:    c.NameTag,
+		Templates:  Messages(c.Messages),
+		Errors:     c.Errors,
+		Receivers:  c.Receiver,
+		Unexported: c.Unexport != nil && *c.Unexport,
+	}
+
+	if s.NameTag == GoNames {
+		s.NameTag = ""
+	}
+
+	return s
+}
+
+// Apply sets the TagKeys, Errors, NameTag, Templates, Aliases, Receivers and Unexported of the generator
+// to the settings of the Config.
+func (c Config) Apply() {
+	s := c.Settings()
+	TagKeys, Aliases, NameTag, Templates = s.TagKeys, s.Aliases, s.NameTag, s.Templates
+	Errors, Receivers, Unexported = s.Errors, s.Receivers, s.Unexported
+}
+
+// Print returns the YAML of the Config.
+func (c Config) Print() ([]byte, error) {
+	buf := &bytes.Buffer{}
+	enc := yaml.NewEncoder(buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(c); err != nil {
+		return nil, fmt.Errorf("encoding config: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
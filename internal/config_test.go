@@ -0,0 +1,157 @@
+package internal_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/paluszkiewiczB/validator/internal"
+)
+
+const config = `tag-keys: [binding, validate]
+errors: collect-all
+messages:
+  required: '{field} is required'
+aliases:
+  iscolor: hexcolor|rgb
+out: '{in}_validation.go'
+unexported: true
+packages:
+  api/v1:
+    name-tag: json
+    receiver: pointer
+    unexported: false
+    messages:
+      max: '{field} is too long'
+`
+
+func Test_FindConfig(t *testing.T) {
+	root := t.TempDir()
+	for _, dir := range []string{"api/v1", "web"} {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0o750); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := os.WriteFile(filepath.Join(root, internal.ConfigFile), []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	unexported := true
+	base := internal.Config{
+		TagKeys:  []string{"binding", "validate"},
+		Errors:   internal.CollectAll,
+		NameTag:  internal.GoNames,
+		Messages: map[string]string{"required": "{field} is required"},
+		Aliases:  map[string]string{"iscolor": "hexcolor|rgb"},
+		Out:      "{in}_validation.go",
+		OutPkg:   "main",
+		Receiver: internal.AutoReceiver,
+		Unexport: &unexported,
+		Debug:    new(bool),
+	}
+
+	override := base
+	override.NameTag = "json"
+	override.Receiver = internal.PointerReceiver
+	override.Unexport = new(bool)
+	override.Messages = map[string]string{"required": "{field} is required", "max": "{field} is too long"}
+
+	for dir, expected := range map[string]internal.Config{".": base, "web": base, "api/v1": override} {
+		t.Run(dir, func(t *testing.T) {
+			cfg, file, err := internal.FindConfig(filepath.Join(root, dir))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if file != filepath.Join(root, internal.ConfigFile) {
+				t.Errorf("expected config file in %q, got: %q", root, file)
+			}
+
+			if !reflect.DeepEqual(expected, cfg) {
+				t.Errorf("expected config %#v, got: %#v", expected, cfg)
+			}
+		})
+	}
+
+	t.Run("print", func(t *testing.T) {
+		for dir, expected := range map[string]string{".": "unexported: true\n", "api/v1": "unexported: false\n"} {
+			cfg, _, err := internal.FindConfig(filepath.Join(root, dir))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			content, err := cfg.Print()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !strings.Contains(string(content), expected) || !strings.Contains(string(content), "debug: false\n") {
+				t.Errorf("expected %q and the default debug in the config of %q, got:\n%s", expected, dir, content)
+			}
+		}
+	})
+
+	t.Run("out", func(t *testing.T) {
+		if out := base.OutFile("models/user.go"); out != filepath.Join("models", "user_validation.go") {
+			t.Errorf("expected models/user_validation.go, got: %q", out)
+		}
+
+		if out := base.OutFile("user.go"); out != "user_validation.go" {
+			t.Errorf("expected user_validation.go, got: %q", out)
+		}
+	})
+}
+
+func Test_FindConfig_Defaults(t *testing.T) {
+	cfg, file, err := internal.FindConfig(t.TempDir())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if file != "" || !reflect.DeepEqual(internal.DefaultConfig(), cfg) {
+		t.Errorf("expected defaults, got: %#v from %q", cfg, file)
+	}
+}
+
+func Test_ParseConfig_Errors(t *testing.T) {
+	for content, msg := range map[string]string{
+		"errors: all":                              `unknown errors "all"`,
+		"tag-key: binding":                         "field tag-key not found",
+		"aliases:\n  required: min=1":              `alias "required" shadows the rule`,
+		"aliases:\n  empty: ''":                    `alias "empty" has no rules`,
+		"receiver: reference":                      `unknown receiver "reference"`,
+		"out: gen/validation.go":                   `out "gen/validation.go" must be the name of the file`,
+		"packages:\n  api:\n    errors: all":       `package "api": unknown errors "all"`,
+		"packages:\n  api:\n    packages: {a: {}}": `package "api": overrides can not declare packages`,
+	} {
+		t.Run(content, func(t *testing.T) {
+			_, err := internal.ParseConfig([]byte(content))
+			if err == nil || !strings.Contains(err.Error(), msg) {
+				t.Errorf("expected error %q, got: %v", msg, err)
+			}
+		})
+	}
+}
+
+func Test_UseMessages_MergesConfig(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "messages.json")
+	if err := os.WriteFile(file, []byte(`{"required": "{field} must be provided"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	templates := internal.Templates
+	t.Cleanup(func() { internal.Templates = templates })
+	internal.Templates = internal.Messages{internal.Required: "{field} is required", internal.Gte: "{field} is too small"}
+
+	if err := internal.UseMessages(file); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := internal.Messages{internal.Required: "{field} must be provided", internal.Gte: "{field} is too small"}
+	if !reflect.DeepEqual(expected, internal.Templates) {
+		t.Errorf("expected templates %v, got: %v", expected, internal.Templates)
+	}
+}
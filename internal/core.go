@@ -4,9 +4,12 @@ import (
 	"errors"
 	"fmt"
 	"go/ast"
+	"go/parser"
 	"go/token"
 	"go/types"
+	"regexp"
 	"slices"
+	"strconv"
 	"strings"
 
 	"golang.org/x/exp/maps"
@@ -16,6 +19,29 @@ type Struct struct {
 	Name   string
 	Fields []Field
 	Ast    *ast.StructType
+	// Doc is the doc comment of the type declaration, may be nil
+	Doc *ast.CommentGroup
+	// TypeParams are the type parameters of the generic struct, in the order of the declaration.
+	TypeParams []TypeParam
+	// Receiver is the kind of the receiver of the generated methods, see Receivers and DetectReceivers.
+	Receiver ReceiverKind
+	// Unexported is true, when the generated methods are unexported, see Unexported.
+	Unexported bool
+	// Errors is the ErrorStyle of the generated methods, see Errors.
+	Errors ErrorStyle
+	// Templates override DefaultMessages in the error messages of the struct, see Templates and Message.
+	Templates Messages
+}
+
+// Groups returns the sorted names of all the validation groups declared by the fields of the struct.
+func (s Struct) Groups() []string {
+	var groups []string
+	for _, f := range s.Fields {
+		groups = append(groups, maps.Keys(f.Groups)...)
+	}
+
+	slices.Sort(groups)
+	return slices.Compact(groups)
 }
 
 type Field struct {
@@ -23,6 +49,32 @@ type Field struct {
 	Type        Type
 	Ast         *ast.Field
 	Validations Validations
+	// Groups are the validations of the named groups, see ParseGroups.
+	Groups Groups
+	// Messages are the templates of the error messages declared by the field, see ParseMessages.
+	Messages Messages
+	// ExternalName is the name of the field in the tag NameTag, e.g. 'string_pointer' for `json:"string_pointer"`.
+	ExternalName string
+	// Nested is true, when the field is validated with the method Validate of its type parameter after the rules,
+	// see TypeParam.Validator.
+	Nested bool
+	// Modifiers are the modifiers of the ModTagKey applied by the generated method Normalize, see ParseModifiers.
+	Modifiers []Rule
+	// Access is the expression of the value in the generated code, when it is not the field of the receiver,
+	// e.g. the element of the slice, see Elem.
+	Access string
+	// Index is the variable of the index of the element, which the errors report, e.g. 'tags[1]', see Elem.
+	Index string
+}
+
+// Reported returns the name of the field reported in the errors.
+// It is the ExternalName, if the field has one, or the Name otherwise.
+func (f Field) Reported() string {
+	if f.ExternalName != "" {
+		return f.ExternalName
+	}
+
+	return f.Name
 }
 
 func NewField(f *ast.Field, v Validations) Field {
@@ -34,6 +86,29 @@ func NewField(f *ast.Field, v Validations) Field {
 	}
 }
 
+// InGroup returns the field with the Validations of the group.
+// Validations of the group replace the default ones, if the field does not declare the group,
+// the default Validations are used.
+func (f Field) InGroup(group string) Field {
+	if vals, ok := f.Groups[group]; ok {
+		f.Validations = vals
+	}
+
+	return f
+}
+
+// Elem returns the field of the elements of the slice field with the validations of the elements, see Validations.Dive.
+// The generated code validates every element in the loop over the field.
+func (f Field) Elem(vals Validations) Field {
+	f.Type = f.Type.Elem()
+	f.Validations = vals
+	f.Groups = nil
+	f.Nested = false
+	f.Modifiers = nil
+	f.Access, f.Index = "elem", "idx"
+	return f
+}
+
 func (f Field) IsSlice() string {
 	return fmt.Sprintf("%s %s", f.Name, f.Type)
 }
@@ -58,31 +133,206 @@ func (t Type) IsPtr() bool {
 	return t[0] == '*'
 }
 
-// Validations is a parsed struct tag 'required'.
+func (t Type) IsBool() bool {
+	return t == "bool"
+}
+
+// IsInteger returns true for the predeclared integer types.
+func (t Type) IsInteger() bool {
+	switch t {
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64", "uintptr", "byte", "rune":
+		return true
+	}
+
+	return false
+}
+
+// IsNumber returns true for the predeclared integer and floating-point types.
+func (t Type) IsNumber() bool {
+	return t.IsInteger() || t == "float32" || t == "float64"
+}
+
+// Deref returns the type pointed by the pointer type, or the type itself.
+func (t Type) Deref() Type {
+	for t.IsPtr() {
+		t = t[1:]
+	}
+
+	return t
+}
+
+// Elem returns the type of the elements of the slice, or the values of the map.
+// It returns empty Type for other types.
+func (t Type) Elem() Type {
+	switch {
+	case t.IsSlice():
+		return t[2:]
+	case t.IsMap():
+		depth := 0
+		for i, c := range t[len("map"):] {
+			switch c {
+			case '[':
+				depth++
+			case ']':
+				depth--
+			}
+
+			if depth == 0 {
+				return t[len("map")+i+1:]
+			}
+		}
+	}
+
+	return ""
+}
+
+// Rule is a single validation rule of the struct tag, e.g. 'eqfield=Other'.
+type Rule struct {
+	// Name of the rule, e.g. 'eqfield'.
+	Name string
+	// Param of the rule, e.g. 'Other', empty if the rule has no parameter.
+	Param string
+	// Pos is the position of the rule in the source, or relative to the tag, see ParseGroupsAt.
+	Pos token.Position
+	// Alias is the name of the alias the rule was expanded from, see Aliases, empty if it was not.
+	Alias string
+}
+
+func (r Rule) String() string {
+	if r.Param == "" {
+		return r.Name
+	}
+
+	return r.Name + "=" + r.Param
+}
+
+// Alternatives are the rules combined with the pipe, e.g. 'rgb|rgba|hexcolor'.
+// The field is valid, when it satisfies any of the rules.
+type Alternatives []Rule
+
+// Tag returns the names of the rules joined with the pipe, e.g. 'rgb|rgba|hexcolor'.
+// Rules expanded from the alias are named with the alias once, e.g. 'iscolor' or 'len|iscolor', see ActualTag.
+func (a Alternatives) Tag() string {
+	var names []string
+	for i, r := range a {
+		if r.Alias == "" {
+			names = append(names, r.Name)
+			continue
+		}
+
+		if i == 0 || a[i-1].Alias != r.Alias {
+			names = append(names, r.Alias)
+		}
+	}
+
+	return strings.Join(names, "|")
+}
+
+// ActualTag returns the names of the rules joined with the pipe, with the aliases expanded, e.g. 'hexcolor|rgb'.
+func (a Alternatives) ActualTag() string {
+	return strings.Join(mapSlice(a, func(r Rule) string { return r.Name }), "|")
+}
+
+func (a Alternatives) String() string {
+	return strings.Join(mapSlice(a, Rule.String), "|")
+}
+
+// Validations is a parsed struct tag 'validate', the rules are in the order of the tag.
+// Rules can repeat and the generated code validates them in this order, returning the first violation.
 // For field:
 //
-//	Name string `validate:"required,oneof=red green blue,oneof=r g b"`
+//	Name string `validate:"required,oneof=red green blue,oneof=r g b,rgb|rgba"`
 //
-// the value would be: map[string][]string{"required":{}, "oneof":{"red green blue","r g b"}}
-type Validations map[string][]string
+// the value would be:
+//
+//	Validations{{{Name: "required"}}, {{Name: "oneof", Param: "red green blue"}}, {{Name: "oneof", Param: "r g b"}}, {{Name: "rgb"}, {Name: "rgba"}}}
+type Validations []Alternatives
 
-func ParseValidations(tag string) (Validations, error) {
-	state := newParseState()
-	for i, c := range tag {
-		err := state.accept(c)
-		if err != nil {
-			return nil, fmt.Errorf("parsing tag: %q[%d] - rune %q,  %w", tag, i, string(c), err)
+func (v Validations) String() string {
+	return strings.Join(mapSlice(v, Alternatives.String), ",")
+}
+
+// Dive splits the validations at the rule Dive into the validations of the field and the validations of its elements.
+// It returns false, when the validations have no Dive.
+func (v Validations) Dive() (Validations, Validations, bool) {
+	for i, alts := range v {
+		if len(alts) == 1 && alts[0].Name == Dive {
+			return v[:i], v[i+1:], true
 		}
 	}
 
-	return state.build()
+	return v, nil, false
+}
+
+// DefaultGroup is the group of Validations declared without any group.
+const DefaultGroup = ""
+
+// Groups are the Validations of the validation groups.
+// Group can be declared either with a qualified tag key or inside the tag value, so for field:
+//
+//	Password string `validate:"required;update:omitempty" validate.create:"required,eqfield=Repeated"`
+//
+// the value would be:
+//
+//	Groups{"": {{{Name: "required"}}}, "create": {{{Name: "required"}}, {{Name: "eqfield", Param: "Repeated"}}}, "update": {{{Name: "omitempty"}}}}
+//
+// Rules in the tag value belong to the group declared before the colon, until the semicolon.
+type Groups map[string]Validations
+
+// ParseValidations parses the Validations of the DefaultGroup.
+func ParseValidations(tag string) (Validations, error) {
+	groups, err := ParseGroups(tag)
+	if err != nil {
+		return nil, err
+	}
+
+	return groups[DefaultGroup], nil
+}
+
+// ParseGroups parses the Validations of all the groups, including the DefaultGroup.
+// The tag is the Go string literal, as it is written in the source, e.g. with the backquotes.
+func ParseGroups(tag string) (Groups, error) {
+	return ParseGroupsAt(tag, token.Position{Line: 1, Column: 1})
+}
+
+// ParseGroupsAt is like ParseGroups, but the positions of TagError are relative to the position of the tag in the source.
+func ParseGroupsAt(tag string, pos token.Position) (Groups, error) {
+	return parseTag(tag, pos, CurrentSyntax())
+}
+
+// ParseGroupsOf is like ParseGroupsAt, but it parses the rules with the syntax instead of CurrentSyntax.
+func ParseGroupsOf(tag string, pos token.Position, syntax Syntax) (Groups, error) {
+	return parseTag(tag, pos, syntax)
 }
 
-func FindStructs(f *ast.File) ([]Struct, error) {
+// FindStructs finds the structs with validated fields in the file parsed with the fset, with the CurrentSettings.
+func FindStructs(fset *token.FileSet, f *ast.File) ([]Struct, error) {
+	return FindStructsWith(fset, f, CurrentSettings())
+}
+
+// FindStructsWith is like FindStructs, but it finds the structs with the settings instead of the CurrentSettings.
+// The structs carry the settings of the generation, so GenerateFile does not read the globals.
+func FindStructsWith(fset *token.FileSet, f *ast.File, settings Settings) ([]Struct, error) {
+	markers, err := typeMarkers(fset, f, settings.Syntax)
+	if err != nil {
+		return nil, err
+	}
+
+	decls := typeDecls(f)
 	structs := make(map[string]Struct)
 	var currentType *ast.TypeSpec
+	var currentDecl *ast.GenDecl
 	l := Log
 	ast.Inspect(f, func(n ast.Node) bool {
+		if err != nil {
+			return false
+		}
+
+		if d, ok := n.(*ast.GenDecl); ok {
+			currentDecl = d
+			return true
+		}
+
 		if t, ok := n.(*ast.TypeSpec); ok {
 			l = l.With("type", t.Name)
 			l.Debug("current type")
@@ -95,26 +345,31 @@ func FindStructs(f *ast.File) ([]Struct, error) {
 			return true
 		}
 
+		params := typeParams(currentType, decls)
 		for _, field := range s.Fields.List {
 			l = l.With("field", field.Names[0].Name)
 			l.Debug("checking field")
-			if field.Tag == nil {
-				l.Debug("no tag found, skipping")
-				continue
+			l.Debug("finding validations")
+			var structField Field
+			structField, err = buildField(fset, field, markers[string(Type(types.ExprString(field.Type)).Deref())], settings)
+			nested := isNested(Type(types.ExprString(field.Type)), params)
+			if errors.Is(err, notFound) && nested {
+				err, structField = nil, NewField(field, nil)
 			}
 
-			l.Debug("finding validations")
-			structField, err := buildField(field)
 			if errors.Is(err, notFound) {
+				err = nil
 				continue
 			}
 
 			if err != nil {
-				panic(err)
+				err = fmt.Errorf("struct: %q, field: %q, %w", currentType.Name.Name, field.Names[0].Name, err)
+				return false
 			}
 
 			name := currentType.Name.Name
-			thisField := Struct{Name: name, Fields: []Field{structField}, Ast: s}
+			structField.Nested = nested
+			thisField := Struct{Name: name, Fields: []Field{structField}, Ast: s, Doc: typeDoc(currentDecl, currentType), TypeParams: params}
 			structs[name] = mergeStructs(structs[name], thisField)
 			l = Log
 		}
@@ -122,6 +377,10 @@ func FindStructs(f *ast.File) ([]Struct, error) {
 		return false
 	})
 
+	if err != nil {
+		return nil, err
+	}
+
 	l.Debug("finished finding structs", "map", structs)
 
 	slice := maps.Values(structs)
@@ -129,7 +388,37 @@ func FindStructs(f *ast.File) ([]Struct, error) {
 		return strings.Compare(a.Name, b.Name)
 	})
 
-	return slice, nil
+	for i, str := range slice {
+		if slice[i], err = typeDirectives(str, settings); err != nil {
+			return nil, err
+		}
+	}
+
+	return DetectReceivers(slice, f), nil
+}
+
+// FindStructsInFile parses the Go file at the path and finds its structs, see FindStructs. The comments are parsed,
+// because they carry the markers, the directives and the descriptions of the exported schemas.
+func FindStructsInFile(fset *token.FileSet, path string) ([]Struct, error) {
+	f, err := parser.ParseFile(fset, path, nil, parser.AllErrors|parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("parsing file: %w", err)
+	}
+
+	return FindStructs(fset, f)
+}
+
+// typeDoc returns the doc comment of the type, which is the doc comment of the declaration for `type T struct{}`.
+func typeDoc(d *ast.GenDecl, t *ast.TypeSpec) *ast.CommentGroup {
+	if t.Doc != nil {
+		return t.Doc
+	}
+
+	if d != nil && len(d.Specs) == 1 && d.Specs[0] == t {
+		return d.Doc
+	}
+
+	return nil
 }
 
 func mergeStructs(a, b Struct) Struct {
@@ -154,63 +443,112 @@ func mergeStructs(a, b Struct) Struct {
 		a.Ast = b.Ast
 	}
 
+	if a.Doc == nil {
+		a.Doc = b.Doc
+	}
+
+	if a.TypeParams == nil {
+		a.TypeParams = b.TypeParams
+	}
+
 	return a
 }
 
 var notFound = errors.New("validation not found")
 
-func buildField(f *ast.Field) (Field, error) {
+// buildField builds the field with the validations of the tag and the markers, typeMarkers are the markers of its type.
+func buildField(fset *token.FileSet, f *ast.Field, typeMarkers Groups, settings Settings) (Field, error) {
 	l := Log
-	tag := f.Tag.Value
-	if tag == "" {
-		return Field{}, notFound
+	tag, pos := "``", fset.Position(f.Pos())
+	if f.Tag != nil && f.Tag.Value != "" {
+		tag, pos = f.Tag.Value, fset.Position(f.Tag.Pos())
+	}
+
+	groups, err := ParseGroupsOf(tag, pos, settings.Syntax)
+	if err != nil {
+		return Field{}, fmt.Errorf("parsing validations: %w", err)
 	}
 
-	vals, err := ParseValidations(tag)
+	markers, err := ParseMarkersOf(fset, settings.Syntax, f.Doc, f.Comment)
 	if err != nil {
-		return Field{}, fmt.Errorf("parsing validations: %q, %w", tag, err)
+		return Field{}, fmt.Errorf("parsing markers: %w", err)
+	}
+
+	for group, vals := range typeMarkers {
+		markers[group] = append(slices.Clone(vals), markers[group]...)
 	}
 
-	if len(vals) == 0 {
+	groups, err = MergeMarkers(markers, groups)
+	if err != nil {
+		return Field{}, err
+	}
+
+	mods, err := ParseModifiers(tag, pos)
+	if err != nil {
+		return Field{}, fmt.Errorf("parsing modifiers: %w", err)
+	}
+
+	vals := groups[DefaultGroup]
+	delete(groups, DefaultGroup)
+	if len(vals) == 0 && len(groups) == 0 && len(mods) == 0 {
 		l.Debug("no validations found")
 		return Field{}, notFound
 	}
 
-	l.Debug("found", "validations", vals)
-	return NewField(f, vals), nil
+	l.Debug("found", "validations", vals, "groups", groups, "modifiers", mods)
+	field := NewField(f, vals)
+	field.Modifiers = mods
+	if len(groups) != 0 {
+		field.Groups = groups
+	}
+
+	field.Messages, err = ParseMessages(tag, append(maps.Values(groups), vals)...)
+	if err != nil {
+		return Field{}, fmt.Errorf("parsing messages: %q, %w", tag, err)
+	}
+
+	if settings.NameTag != "" {
+		field.ExternalName, err = ParseName(tag, settings.NameTag)
+		if err != nil {
+			return Field{}, fmt.Errorf("parsing name: %q, %w", tag, err)
+		}
+	}
+
+	return field, nil
 }
 
 const (
 	Required = "required"
 	Eqfield  = "eqfield"
 	Gte      = "gte"
+	Min      = "min"
+	Max      = "max"
+	Len      = "len"
+	Oneof    = "oneof"
+	Regexp   = "regexp"
+	Email    = "email"
+	URL      = "url"
+	Dive     = "dive"
 )
 
-// deprecated: use Generator instead.
-type ValidatorFunc func(key string, str Struct, field Field) (ast.Stmt, error)
-
-func (f ValidatorFunc) AsGenerator() Generator {
-	return GeneratorFunc(func(key string, str Struct, field Field) (Generated, error) {
-		stmt, err := f(key, str, field)
-		return Generated{Stmts: []ast.Stmt{stmt}}, err
-	})
-}
-
 type Generator interface {
-	Generate(key string, str Struct, field Field) (Generated, error)
+	Generate(rule Rule, str Struct, field Field) (Generated, error)
 }
 
 type Generated struct {
-	// Stmts are the pieces of generated code
-	Stmts []ast.Stmt
-	// Imports are the import paths required by the generated Stmts
+	// Cond is the condition which is true, when the field satisfies the rule
+	Cond ast.Expr
+	// Imports are the import paths required by the generated Cond
 	Imports []string
+	// Float is true, when the Cond compares floating-point numbers, which can be NaN. Such Cond is not negated
+	// by the operator, because NaN satisfies neither 'x <= 0.5' nor 'x > 0.5'.
+	Float bool
 }
 
-type GeneratorFunc func(key string, str Struct, field Field) (Generated, error)
+type GeneratorFunc func(rule Rule, str Struct, field Field) (Generated, error)
 
-func (f GeneratorFunc) Generate(key string, str Struct, field Field) (Generated, error) {
-	return f(key, str, field)
+func (f GeneratorFunc) Generate(rule Rule, str Struct, field Field) (Generated, error) {
+	return f(rule, str, field)
 }
 
 func GeneratorFor(validation string) Generator {
@@ -219,73 +557,222 @@ func GeneratorFor(validation string) Generator {
 		return nil
 	}
 
-	return v
+	return v.Generator
+}
+
+// validator is the registered rule: the generator of its code and the evaluator of the same rule at runtime.
+type validator struct {
+	Generator
+	Evaluator
+}
+
+var validators = map[string]validator{
+	Required: {forKey(Required, hasOptions(0, required)), EvaluatorFunc(evalRequired)},
+	Eqfield:  {forKey(Eqfield, hasOptions(1, eqfield)), EvaluatorFunc(evalEqfield)},
+	Gte:      {forKey(Gte, hasOptions(1, gte)), EvaluatorFunc(evalGte)},
+	Min:      {forKey(Min, hasOptions(1, length(token.GEQ))), evalLength(token.GEQ)},
+	Max:      {forKey(Max, hasOptions(1, length(token.LEQ))), evalLength(token.LEQ)},
+	Len:      {forKey(Len, hasOptions(1, length(token.EQL))), evalLength(token.EQL)},
+	Oneof:    {forKey(Oneof, hasOptions(1, oneof)), EvaluatorFunc(evalOneof)},
+	Regexp:   {forKey(Regexp, hasOptions(1, matchRegexp)), EvaluatorFunc(evalRegexp)},
+	Email:    {forKey(Email, hasOptions(0, email)), EvaluatorFunc(evalEmail)},
+	URL:      {forKey(URL, hasOptions(0, isURL)), EvaluatorFunc(evalURL)},
+	Dive:     {forKey(Dive, hasOptions(0, dive)), EvaluatorFunc(evalDive)},
+}
+
+// GenerateValidation generates the statement returning validation.FieldError,
+// when the field does not satisfy any of the alternatives.
+func GenerateValidation(alts Alternatives, str Struct, field Field) (ast.Stmt, []string, error) {
+	var cond ast.Expr
+	var imports []string
+	float := false
+	for _, rule := range alts {
+		gen := GeneratorFor(rule.Name)
+		if gen == nil {
+			return nil, nil, fmt.Errorf("%s: validator not found for struct: %q, field: %q, validation: %q", rule.Pos, str.Name, field.Name, rule.Name)
+		}
+
+		generated, err := gen.Generate(rule, str, field)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", rule.Pos, err)
+		}
+
+		imports = append(imports, generated.Imports...)
+		float = float || generated.Float
+		if cond == nil {
+			cond = generated.Cond
+			continue
+		}
+
+		cond = &ast.BinaryExpr{X: cond, Op: token.LOR, Y: generated.Cond}
+	}
+
+	var negated ast.Expr = &ast.UnaryExpr{Op: token.NOT, X: &ast.ParenExpr{X: cond}}
+	if !float {
+		negated = not(cond)
+	}
+
+	body, imps := errorBlock(alts, str, field)
+	return &ast.IfStmt{Cond: negated, Body: body}, append(imports, imps...), nil
 }
 
-var validators = map[string]Generator{
-	Required: forKey(Required, hasOptions(0, required)).AsGenerator(),
-	Eqfield:  forKey(Eqfield, hasOptions(1, eqfield)).AsGenerator(),
-	Gte:      forKey(Gte, hasOptions(1, gte)).AsGenerator(),
+// not negates the condition, comparisons are negated by the operator, e.g. 'a == b' becomes 'a != b'.
+// The comparisons of the floating-point numbers must be negated with '!', see Generated.Float.
+func not(cond ast.Expr) ast.Expr {
+	negated := map[token.Token]token.Token{
+		token.EQL: token.NEQ,
+		token.NEQ: token.EQL,
+		token.LSS: token.GEQ,
+		token.GEQ: token.LSS,
+		token.GTR: token.LEQ,
+		token.LEQ: token.GTR,
+	}
+
+	if b, ok := cond.(*ast.BinaryExpr); ok {
+		if op, ok := negated[b.Op]; ok {
+			return &ast.BinaryExpr{X: b.X, Op: op, Y: b.Y}
+		}
+	}
+
+	return &ast.UnaryExpr{Op: token.NOT, X: &ast.ParenExpr{X: cond}}
 }
 
 // TODO: always converts the field to float64, should be able to:
 // 1. detect that field already is float64
 // 2. compare fields of the same type without conversion (e.g. uint8 to uint8)
-func gte(key string, str Struct, field Field) (ast.Stmt, error) {
-	than := field.Validations[key][0]
-	return &ast.IfStmt{
-		Init: &ast.AssignStmt{
-			Lhs: []ast.Expr{&ast.Ident{Name: "val"}, &ast.Ident{Name: "than"}},
-			Rhs: []ast.Expr{&ast.Ident{Name: cast("float64", FieldAccess(str, field))}, &ast.Ident{Name: cast("float64", FieldNameAccess(str, than))}},
-			Tok: token.DEFINE,
-		},
+// Fields of the ordered type parameter are compared without the conversion, the other field must be of the same type.
+func gte(rule Rule, str Struct, field Field) (Generated, error) {
+	if p, ok := str.TypeParam(field.Type); ok {
+		if !p.Ordered() {
+			return Generated{}, fmt.Errorf("unsupported type for validation: %q, type parameter %s %s is not ordered", rule.Name, p.Name, p.Constraint)
+		}
+
+		if other := fieldType(str, rule.Param); other != field.Type {
+			return Generated{}, fmt.Errorf("validation %q expects field %q of type %s, got: %s", rule.Name, rule.Param, field.Type, other)
+		}
+
+		return Generated{
+			Cond: &ast.BinaryExpr{
+				X:  &ast.Ident{Name: FieldAccess(str, field)},
+				Op: token.GEQ,
+				Y:  &ast.Ident{Name: FieldNameAccess(str, rule.Param)},
+			},
+			Float: !p.Integer(),
+		}, nil
+	}
+
+	return Generated{
 		Cond: &ast.BinaryExpr{
-			X:  &ast.Ident{Name: "val"},
-			Op: token.LSS,
-			Y:  &ast.Ident{Name: "than"},
+			X:  &ast.Ident{Name: cast("float64", FieldAccess(str, field))},
+			Op: token.GEQ,
+			Y:  &ast.Ident{Name: cast("float64", FieldNameAccess(str, rule.Param))},
 		},
-		Body: errorBlock(`errors.New("field \"%s\" must greater or equal than \"%s\"")`, field.Name, than),
-		Else: nil,
+		Float: !field.Type.IsInteger() || !fieldType(str, rule.Param).IsInteger(),
 	}, nil
 }
 
-func forKey(supported string, fun ValidatorFunc) ValidatorFunc {
-	return func(key string, str Struct, field Field) (ast.Stmt, error) {
-		if key != supported {
-			return nil, fmt.Errorf("unsupported validation key: %q, supported: %q", key, supported)
+// length returns the generator comparing the param with the length of strings (in runes), slices and maps,
+// or with the value of numbers. The param must be the constant of the type of the number, the generated comparison
+// of the overflowing constant, e.g. 'min=-1' of uint, would not compile.
+func length(op token.Token) GeneratorFunc {
+	return func(rule Rule, str Struct, field Field) (Generated, error) {
+		access := &ast.Ident{Name: FieldAccess(str, field)}
+		p, isParam := str.TypeParam(field.Type)
+		switch t := field.Type; {
+		case isParam && p.Integer():
+			if _, err := strconv.ParseInt(rule.Param, 10, 64); err != nil {
+				return Generated{}, fmt.Errorf("validation %q expects integer, got: %q", rule.Name, rule.Param)
+			}
+		case isParam && p.Number():
+			if _, err := strconv.ParseFloat(rule.Param, 64); err != nil {
+				return Generated{}, fmt.Errorf("validation %q expects number, got: %q", rule.Name, rule.Param)
+			}
+		case isParam:
+			return Generated{}, fmt.Errorf("unsupported type for validation: %q, type parameter %s %s is not a number", rule.Name, p.Name, p.Constraint)
+		case t.IsString():
+			if n, err := strconv.Atoi(rule.Param); err != nil || n < 0 {
+				return Generated{}, fmt.Errorf("validation %q expects non-negative integer, got: %q", rule.Name, rule.Param)
+			}
+
+			return Generated{
+				Cond:    &ast.BinaryExpr{X: &ast.CallExpr{Fun: &ast.Ident{Name: "utf8.RuneCountInString"}, Args: []ast.Expr{access}}, Op: op, Y: &ast.Ident{Name: rule.Param}},
+				Imports: []string{"unicode/utf8"},
+			}, nil
+		case t.IsSlice(), t.IsMap():
+			if n, err := strconv.Atoi(rule.Param); err != nil || n < 0 {
+				return Generated{}, fmt.Errorf("validation %q expects non-negative integer, got: %q", rule.Name, rule.Param)
+			}
+
+			return Generated{
+				Cond: &ast.BinaryExpr{X: &ast.CallExpr{Fun: &ast.Ident{Name: "len"}, Args: []ast.Expr{access}}, Op: op, Y: &ast.Ident{Name: rule.Param}},
+			}, nil
+		case t.IsInteger():
+			if _, err := strconv.ParseInt(rule.Param, 10, 64); err != nil {
+				return Generated{}, fmt.Errorf("validation %q expects integer, got: %q", rule.Name, rule.Param)
+			}
+
+			if !constantFits(t, rule.Param) {
+				return Generated{}, fmt.Errorf("validation %q value %s overflows type %q", rule.Name, rule.Param, t)
+			}
+		case t.IsNumber():
+			if _, err := strconv.ParseFloat(rule.Param, 64); err != nil {
+				return Generated{}, fmt.Errorf("validation %q expects number, got: %q", rule.Name, rule.Param)
+			}
+
+			if !constantFits(t, rule.Param) {
+				return Generated{}, fmt.Errorf("validation %q value %s overflows type %q", rule.Name, rule.Param, t)
+			}
+		default:
+			return Generated{}, fmt.Errorf("unsupported type for validation: %q, type: %q", rule.Name, t)
+		}
+
+		float := !field.Type.IsInteger() && !(isParam && p.Integer())
+		return Generated{Cond: &ast.BinaryExpr{X: access, Op: op, Y: &ast.Ident{Name: rule.Param}}, Float: float}, nil
+	}
+}
+
+func forKey(supported string, fun GeneratorFunc) GeneratorFunc {
+	return func(rule Rule, str Struct, field Field) (Generated, error) {
+		if rule.Name != supported {
+			return Generated{}, fmt.Errorf("unsupported validation key: %q, supported: %q", rule.Name, supported)
 		}
 
-		return fun(key, str, field)
+		return fun(rule, str, field)
 	}
 }
 
-func hasOptions(count int, fun ValidatorFunc) ValidatorFunc {
-	return func(key string, str Struct, field Field) (ast.Stmt, error) {
-		got := len(field.Validations[key])
+func hasOptions(count int, fun GeneratorFunc) GeneratorFunc {
+	return func(rule Rule, str Struct, field Field) (Generated, error) {
+		got := 0
+		if rule.Param != "" {
+			got = 1
+		}
+
 		if got != count {
-			return nil, fmt.Errorf("validation %q expects exactly %d option, but got: %d - %v", key, count, got, field.Validations[key])
+			return Generated{}, fmt.Errorf("validation %q expects exactly %d option, but got: %d - %q", rule.Name, count, got, rule.Param)
 		}
 
-		return fun(key, str, field)
+		return fun(rule, str, field)
 	}
 }
 
-func eqfield(key string, str Struct, field Field) (ast.Stmt, error) {
-	eqTo := field.Validations[key][0]
-	return &ast.IfStmt{
+func eqfield(rule Rule, str Struct, field Field) (Generated, error) {
+	if p, ok := str.TypeParam(field.Type); ok && !p.Comparable() {
+		return Generated{}, fmt.Errorf("unsupported type for validation: %q, type parameter %s %s is not comparable", rule.Name, p.Name, p.Constraint)
+	}
+
+	return Generated{
 		Cond: &ast.BinaryExpr{
 			X:  &ast.Ident{Name: FieldAccess(str, field)},
-			Op: token.NEQ,
-			Y:  &ast.Ident{Name: FieldNameAccess(str, eqTo)},
+			Op: token.EQL,
+			Y:  &ast.Ident{Name: FieldNameAccess(str, rule.Param)},
 		},
-		Body: errorBlock(`errors.New("field \"%s\" must be equal to \"%s\"")`, field.Name, eqTo),
-		Else: nil,
 	}, nil
 }
 
-func required(key string, str Struct, field Field) (ast.Stmt, error) {
+func required(rule Rule, str Struct, field Field) (Generated, error) {
 	l := Log
-	l.With("key", key)
+	l.With("key", rule.Name)
 	l.Debug("validating")
 
 	switch t := field.Type; {
@@ -303,7 +790,118 @@ func required(key string, str Struct, field Field) (ast.Stmt, error) {
 		return requireNonNil(str, field)
 	}
 
-	return nil, fmt.Errorf("unsupported type for validation: %q", Required)
+	// the zero value of the comparable type parameter, e.g. '*new(T)'
+	if p, ok := str.TypeParam(field.Type); ok {
+		if !p.Comparable() {
+			return Generated{}, fmt.Errorf("unsupported type for validation: %q, type parameter %s %s is not comparable", Required, p.Name, p.Constraint)
+		}
+
+		return Generated{
+			Cond: &ast.BinaryExpr{
+				X:  &ast.Ident{Name: FieldAccess(str, field)},
+				Op: token.NEQ,
+				Y:  &ast.Ident{Name: "*new(" + p.Name + ")"},
+			},
+		}, nil
+	}
+
+	return Generated{}, fmt.Errorf("unsupported type for validation: %q", Required)
+}
+
+// oneof compares the string or the integer with each of the values of the param, see oneofValues.
+func oneof(rule Rule, str Struct, field Field) (Generated, error) {
+	t := field.Type
+	if !t.IsString() && !t.IsInteger() {
+		return Generated{}, fmt.Errorf("unsupported type for validation: %q, type: %q", rule.Name, t)
+	}
+
+	var cond ast.Expr
+	for _, v := range oneofValues(rule.Param) {
+		lit := strconv.Quote(v)
+		if t.IsInteger() {
+			_, errInt := strconv.ParseInt(v, 10, 64)
+			_, errUint := strconv.ParseUint(v, 10, 64)
+			if errInt != nil && errUint != nil {
+				return Generated{}, fmt.Errorf("validation %q expects integers, got: %q", rule.Name, v)
+			}
+
+			if !constantFits(t, v) {
+				return Generated{}, fmt.Errorf("validation %q value %s overflows type %q", rule.Name, v, t)
+			}
+
+			lit = v
+		}
+
+		eq := &ast.BinaryExpr{X: &ast.Ident{Name: FieldAccess(str, field)}, Op: token.EQL, Y: &ast.Ident{Name: lit}}
+		if cond == nil {
+			cond = eq
+			continue
+		}
+
+		cond = &ast.BinaryExpr{X: cond, Op: token.LOR, Y: eq}
+	}
+
+	return Generated{Cond: cond}, nil
+}
+
+// oneofValues returns the values of the param of oneof separated with the spaces, like go-playground/validator
+// the values with the spaces can be quoted with the single quotes, e.g. 'red green' blue.
+func oneofValues(param string) []string {
+	values := oneofSplit.FindAllString(param, -1)
+	for i, v := range values {
+		values[i] = strings.TrimSuffix(strings.TrimPrefix(v, "'"), "'")
+	}
+
+	return values
+}
+
+var oneofSplit = regexp.MustCompile(`'[^']*'|\S+`)
+
+// matchRegexp matches the string with the pattern of the param, which must compile at generation time.
+func matchRegexp(rule Rule, str Struct, field Field) (Generated, error) {
+	if !field.Type.IsString() {
+		return Generated{}, fmt.Errorf("unsupported type for validation: %q, type: %q", rule.Name, field.Type)
+	}
+
+	if _, err := regexp.Compile(rule.Param); err != nil {
+		return Generated{}, fmt.Errorf("validation %q expects regular expression, got: %q, %w", rule.Name, rule.Param, err)
+	}
+
+	return Generated{
+		Cond:    &ast.CallExpr{Fun: &ast.Ident{Name: "validation.MatchString"}, Args: []ast.Expr{stringLit(rule.Param), &ast.Ident{Name: FieldAccess(str, field)}}},
+		Imports: []string{ValidationPkg},
+	}, nil
+}
+
+func email(rule Rule, str Struct, field Field) (Generated, error) {
+	if !field.Type.IsString() {
+		return Generated{}, fmt.Errorf("unsupported type for validation: %q, type: %q", rule.Name, field.Type)
+	}
+
+	return Generated{
+		Cond:    &ast.CallExpr{Fun: &ast.Ident{Name: "validation.IsEmail"}, Args: []ast.Expr{&ast.Ident{Name: FieldAccess(str, field)}}},
+		Imports: []string{ValidationPkg},
+	}, nil
+}
+
+func isURL(rule Rule, str Struct, field Field) (Generated, error) {
+	if !field.Type.IsString() {
+		return Generated{}, fmt.Errorf("unsupported type for validation: %q, type: %q", rule.Name, field.Type)
+	}
+
+	return Generated{
+		Cond:    &ast.CallExpr{Fun: &ast.Ident{Name: "validation.IsURL"}, Args: []ast.Expr{&ast.Ident{Name: FieldAccess(str, field)}}},
+		Imports: []string{ValidationPkg},
+	}, nil
+}
+
+// dive is satisfied by any slice, the validations after it are the validations of the elements, see Validations.Dive.
+func dive(rule Rule, _ Struct, field Field) (Generated, error) {
+	if !field.Type.IsSlice() {
+		return Generated{}, fmt.Errorf("unsupported type for validation: %q, type: %q", rule.Name, field.Type)
+	}
+
+	return Generated{Cond: &ast.Ident{Name: "true"}}, nil
 }
 
 func mapSlice[S ~[]T, T, R any](slice S, f func(T) R) []R {
@@ -314,50 +912,109 @@ func mapSlice[S ~[]T, T, R any](slice S, f func(T) R) []R {
 	return out
 }
 
-func requireNonZeroLength(str Struct, field Field) (ast.Stmt, error) {
-	return &ast.IfStmt{
+// fieldType returns the type of the field of the struct, or empty Type, when the struct has no such field.
+func fieldType(str Struct, name string) Type {
+	if str.Ast == nil {
+		return ""
+	}
+
+	for _, f := range str.Ast.Fields.List {
+		for _, ident := range f.Names {
+			if ident.Name == name {
+				return Type(types.ExprString(f.Type))
+			}
+		}
+	}
+
+	return ""
+}
+
+func requireNonZeroLength(str Struct, field Field) (Generated, error) {
+	return Generated{
 		Cond: &ast.BinaryExpr{
 			X:  &ast.CallExpr{Fun: &ast.Ident{Name: "len"}, Args: []ast.Expr{&ast.Ident{Name: FieldAccess(str, field)}}},
-			Op: token.EQL,
+			Op: token.NEQ,
 			Y:  &ast.Ident{Name: "0"},
 		},
-		Body: errorBlock(`errors.New("field \"%s\" is required")`, field.Name),
 	}, nil
 }
 
-func errorBlock(msg string, args ...any) *ast.BlockStmt {
+// ValidationPkg is the import path of the package with the types used by the generated code.
+const ValidationPkg = "github.com/paluszkiewiczB/validator/validation"
+
+// errorBlock returns the block returning validation.FieldError with the message of the alternatives,
+// see Message. The Tag of the error names all the alternatives, e.g. 'rgb|rgba'.
+func errorBlock(alts Alternatives, str Struct, field Field) (*ast.BlockStmt, []string) {
+	param := ""
+	if len(alts) == 1 {
+		param = alts[0].Param
+	}
+
+	msg, imports := messageExpr(resolveField(alternativesMessage(alts, str, field), field), str, field)
+	reported, structField := stringLit(field.Reported()), stringLit(field.Name)
+	if field.Index != "" {
+		reported, structField = indexed(field.Reported(), field.Index), indexed(field.Name, field.Index)
+		imports = append(imports, "fmt")
+	}
+
 	return &ast.BlockStmt{
 		List: []ast.Stmt{
 			&ast.ReturnStmt{
-				Results: []ast.Expr{
-					&ast.BasicLit{Kind: token.STRING, Value: fmt.Sprintf(msg, args...)},
-				},
+				Results: []ast.Expr{&ast.UnaryExpr{
+					Op: token.AND,
+					X: &ast.CompositeLit{
+						Type: &ast.Ident{Name: "validation.FieldError"},
+						Elts: []ast.Expr{
+							keyValue("Struct", stringLit(str.Name)),
+							keyValue("Field", reported),
+							keyValue("StructField", structField),
+							keyValue("Tag", stringLit(alts.Tag())),
+							keyValue("ActualTag", stringLit(alts.ActualTag())),
+							keyValue("Param", stringLit(param)),
+							keyValue("Value", &ast.Ident{Name: FieldAccess(str, field)}),
+							keyValue("Message", msg),
+						},
+					},
+				}},
 			},
 		},
+	}, append(imports, ValidationPkg)
+}
+
+// indexed returns the expression of the name of the element with the index, e.g. 'tags[1]'.
+func indexed(name, index string) ast.Expr {
+	return &ast.CallExpr{
+		Fun:  &ast.Ident{Name: "fmt.Sprintf"},
+		Args: []ast.Expr{stringLit(strings.ReplaceAll(name, "%", "%%") + "[%d]"), &ast.Ident{Name: index}},
 	}
 }
 
-func requireNonNil(str Struct, field Field) (ast.Stmt, error) {
-	return &ast.IfStmt{
+func keyValue(key string, value ast.Expr) ast.Expr {
+	return &ast.KeyValueExpr{Key: &ast.Ident{Name: key}, Value: value}
+}
+
+func stringLit(s string) ast.Expr {
+	return &ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(s)}
+}
+
+func requireNonNil(str Struct, field Field) (Generated, error) {
+	return Generated{
 		Cond: &ast.BinaryExpr{
 			X:  &ast.Ident{Name: FieldAccess(str, field)},
-			Op: token.EQL,
+			Op: token.NEQ,
 			Y:  &ast.Ident{Name: "nil"},
 		},
-		Body: &ast.BlockStmt{
-			List: []ast.Stmt{
-				&ast.ReturnStmt{
-					Results: []ast.Expr{
-						&ast.BasicLit{Kind: token.STRING, Value: fmt.Sprintf(`errors.New("field \"%s\" is required")`, field.Name)},
-					},
-				},
-			},
-		},
 	}, nil
 }
 
+// Receiver returns the receiver of the methods of the struct, with the type parameters of the generic struct.
+// It is the pointer receiver for the PointerReceiver.
 func Receiver(s Struct) string {
-	return ReceiverName(s) + " " + s.Name
+	if s.Receiver == PointerReceiver {
+		return ReceiverName(s) + " *" + s.Name + s.TypeArgs()
+	}
+
+	return ReceiverName(s) + " " + s.Name + s.TypeArgs()
 }
 
 func ReceiverName(s Struct) string {
@@ -365,6 +1022,10 @@ func ReceiverName(s Struct) string {
 }
 
 func FieldAccess(s Struct, f Field) string {
+	if f.Access != "" {
+		return f.Access
+	}
+
 	return ReceiverName(s) + "." + f.Name
 }
 
@@ -378,6 +1039,13 @@ func NoError() ast.Stmt {
 	}
 }
 
+// constantFits returns true, when the literal converts to the predeclared type t, e.g. 300 does not fit uint8.
+// The conversion is checked by go/types the way the compiler checks the generated code.
+func constantFits(t Type, lit string) bool {
+	_, err := types.Eval(token.NewFileSet(), nil, token.NoPos, cast(string(t), lit))
+	return err == nil
+}
+
 func cast(as, what string) string {
 	return fmt.Sprintf("%s(%s)", as, what)
 }
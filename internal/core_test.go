@@ -1,7 +1,12 @@
 package internal_test
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"go/format"
+	"go/token"
+	"slices"
 	"strings"
 	"testing"
 
@@ -60,12 +65,21 @@ func newTestLog(t *testing.T) internal.Logger {
 func Test_ParseValidations(t *testing.T) {
 	internal.Log = newTestLog(t)
 
-	// FIXME: write an actual parser for this, or find something ready to use
-	cases := map[string]map[string][]string{
-		raw(`json:"foo"`):                                           nil,
-		raw(`validate:"required"`):                                  {"required": {}},
-		raw(`validate:"required" json:foo"`):                        {"required": {}},
-		raw(`validate:"required,oneof=red green blue,oneof=r g b"`): {"required": {}, "oneof": {"red green blue", "r g b"}},
+	cases := map[string]internal.Validations{
+		raw(`json:"foo"`):                       nil,
+		raw(`validate:"required"`):              {{{Name: "required"}}},
+		raw(`json:"foo" validate:"required"`):   {{{Name: "required"}}},
+		`"validate:\"required,eqfield=Other\""`: {{{Name: "required"}}, {{Name: "eqfield", Param: "Other"}}},
+		raw(`validate:"oneof=a0x2Cb c0x7Cd"`):   {{{Name: "oneof", Param: "a,b c|d"}}},
+		raw(`validate:"eqfield=a\"b"`):          {{{Name: "eqfield", Param: `a"b`}}},
+		raw(`validate:"required,oneof=red green blue,oneof=r g b"`): {
+			{{Name: "required"}}, {{Name: "oneof", Param: "red green blue"}}, {{Name: "oneof", Param: "r g b"}},
+		},
+		raw(`json:"foo,omitempty" validate:"required"`):          {{{Name: "required"}}},
+		raw(`validate:"create:required"`):                        nil,
+		raw(`validate:"eqfield=Other" msg:"{field} is invalid"`): {{{Name: "eqfield", Param: "Other"}}},
+		raw(`validate:"rgb|rgba|hexcolor"`):                      {{{Name: "rgb"}, {Name: "rgba"}, {Name: "hexcolor"}}},
+		raw(`validate:"omitempty,uuid4|len=26"`):                 {{{Name: "omitempty"}}, {{Name: "uuid4"}, {Name: "len", Param: "26"}}},
 	}
 
 	for in, expected := range cases {
@@ -79,29 +93,311 @@ func Test_ParseValidations(t *testing.T) {
 	}
 }
 
-func sameValidations(t *testing.T, a, b map[string][]string) {
-	t.Helper()
-	if len(a) != len(b) {
-		t.Errorf("expected length %d, got %d for: %v vs %v", len(a), len(b), a, b)
-		return
+func Test_ParseValidations_Errors(t *testing.T) {
+	internal.Log = newTestLog(t)
+
+	// column of the offending rune, the backquote is at column 1
+	cases := map[string]struct {
+		column int
+		msg    string
+	}{
+		raw(`validate:"required" json:foo"`): {column: 27, msg: `expected '"' after tag key "json"`},
+		raw(`validate`):                      {column: 10, msg: `expected ':' after tag key "validate"`},
+		raw(`validate:"required`):            {column: 11, msg: `unterminated value of tag key "validate"`},
+		raw(`validate:"a"json:"b"`):          {column: 14, msg: `expected space after value of tag key "validate"`},
+		raw(`validate:"a" validate:"b"`):     {column: 15, msg: `duplicated tag key "validate"`},
+		raw(`validate:"required,,gte=A"`):    {column: 21, msg: `expected rule before ','`},
+		raw(`validate:"required,"`):          {column: 21, msg: `expected rule after ','`},
+		raw(`validate:"required, gte=A"`):    {column: 21, msg: `unexpected space in rule name " gte"`},
+		raw(`validate:"eqfield="`):           {column: 19, msg: `expected value of rule "eqfield" after '='`},
+		raw(`validate:":required"`):          {column: 12, msg: `expected group name before ':'`},
+		raw(`validate:"create:,required"`):   {column: 19, msg: `expected rule of group "create"`},
+		raw(`validate:"create:"`):            {column: 19, msg: `expected rule of group "create"`},
+		`"validate:\"a,,b\""`:                {column: 15, msg: `expected rule before ','`},
 	}
 
-	for k, v := range a {
-		if bv, ok := b[k]; !ok {
-			t.Errorf("missing key in second map: %s", k)
-		} else {
-			if len(v) != len(bv) {
-				t.Errorf("expected length %v, got %v", v, bv)
-				return
+	for in, expected := range cases {
+		t.Run(in, func(t *testing.T) {
+			_, err := internal.ParseGroupsAt(in, token.Position{Filename: "file.go", Line: 3, Column: 1})
+			var tagErr *internal.TagError
+			if !errors.As(err, &tagErr) {
+				t.Fatalf("expected TagError, got: %v", err)
 			}
 
-			for i, vv := range v {
-				if vv != bv[i] {
-					t.Errorf("at position: %d for key: %s expected %s, got %s", i, k, vv, bv[i])
-				}
+			if tagErr.Msg != expected.msg {
+				t.Errorf("expected message %q, got %q", expected.msg, tagErr.Msg)
+			}
+
+			pos := token.Position{Filename: "file.go", Offset: expected.column - 1, Line: 3, Column: expected.column}
+			if tagErr.Pos != pos {
+				t.Errorf("expected position %v, got %v", pos, tagErr.Pos)
+			}
+		})
+	}
+}
+
+func Test_ParseGroups(t *testing.T) {
+	internal.Log = newTestLog(t)
+
+	cases := map[string]internal.Groups{
+		raw(`validate:"required"`): {"": {{{Name: "required"}}}},
+		raw(`validate:"required" validate.create:"required,eqfield=Field"`): {
+			"":       {{{Name: "required"}}},
+			"create": {{{Name: "required"}}, {{Name: "eqfield", Param: "Field"}}},
+		},
+		raw(`validate:"create:required;update:eqfield=Field;gte=Other"`): {
+			"":       {{{Name: "gte", Param: "Other"}}},
+			"create": {{{Name: "required"}}},
+			"update": {{{Name: "eqfield", Param: "Field"}}},
+		},
+		raw(`validate:"eqfield=^a:b$,gte=a;b|eqfield=c;d"`): {
+			"": {{{Name: "eqfield", Param: "^a:b$"}}, {{Name: "gte", Param: "a;b"}, {Name: "eqfield", Param: "c;d"}}},
+		},
+		raw(`validate:"gte=a0x3Bb:c;create:required"`): {
+			"":       {{{Name: "gte", Param: "a;b:c"}}},
+			"create": {{{Name: "required"}}},
+		},
+		raw(`validate:"create:eqfield=a0x3Bb;gte=c:d" validate.update:"eqfield=e;f"`): {
+			"":       {{{Name: "gte", Param: "c:d"}}},
+			"create": {{{Name: "eqfield", Param: "a;b"}}},
+			"update": {{{Name: "eqfield", Param: "e;f"}}},
+		},
+		raw(`json:"foo" validate.create:"required" validate.update:"update:gte=Field"`): {
+			"":       {},
+			"create": {{{Name: "required"}}},
+			"update": {{{Name: "gte", Param: "Field"}}},
+		},
+	}
+
+	for in, expected := range cases {
+		t.Run(in, func(t *testing.T) {
+			out, err := internal.ParseGroups(in)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			if len(expected) != len(out) {
+				t.Errorf("expected groups %v, got %v", expected, out)
+			}
+
+			for group, vals := range expected {
+				sameValidations(t, vals, out[group])
+			}
+		})
+	}
+}
+
+func Test_ParseGroupsOf(t *testing.T) {
+	internal.Log = newTestLog(t)
+
+	keys := []string{"binding", "validate"}
+	cases := map[string]internal.Groups{
+		raw(`binding:"required"`): {"": {{{Name: "required"}}}},
+		raw(`binding:"required,max=3" validate:"min=1,max=5"`): {
+			"": {{{Name: "required"}}, {{Name: "max", Param: "3"}}, {{Name: "min", Param: "1"}}},
+		},
+		raw(`validate:"max=5|len=0" binding:"max=3"`): {
+			"": {{{Name: "max", Param: "3"}}, {{Name: "max", Param: "5"}, {Name: "len", Param: "0"}}},
+		},
+		raw(`binding.create:"required" validate:"create:required,eqfield=Field;gte=Other"`): {
+			"":       {{{Name: "gte", Param: "Other"}}},
+			"create": {{{Name: "required"}}, {{Name: "eqfield", Param: "Field"}}},
+		},
+		raw(`validate.create:"required" bindings:"required"`): {
+			"":       {},
+			"create": {{{Name: "required"}}},
+		},
+	}
+
+	for in, expected := range cases {
+		t.Run(in, func(t *testing.T) {
+			out, err := internal.ParseGroupsOf(in, token.Position{Line: 1, Column: 1}, internal.Syntax{TagKeys: keys})
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			if len(expected) != len(out) {
+				t.Errorf("expected groups %v, got %v", expected, out)
+			}
+
+			for group, vals := range expected {
+				sameValidations(t, vals, out[group])
+			}
+		})
+	}
+}
+
+func Test_ParseGroups_Aliases(t *testing.T) {
+	internal.Log = newTestLog(t)
+	internal.Aliases = map[string]string{"iscolor": "hexcolor|rgb", "name": "required,max=10"}
+	t.Cleanup(func() { internal.Aliases = map[string]string{} })
+
+	cases := map[string]internal.Groups{
+		raw(`validate:"iscolor"`):       {"": {{{Name: "hexcolor"}, {Name: "rgb"}}}},
+		raw(`validate:"len=0|iscolor"`): {"": {{{Name: "len", Param: "0"}, {Name: "hexcolor"}, {Name: "rgb"}}}},
+		raw(`validate:"min=1,name;create:name"`): {
+			"":       {{{Name: "min", Param: "1"}}, {{Name: "required"}}, {{Name: "max", Param: "10"}}},
+			"create": {{{Name: "required"}}, {{Name: "max", Param: "10"}}},
+		},
+	}
+
+	for in, expected := range cases {
+		t.Run(in, func(t *testing.T) {
+			out, err := internal.ParseGroups(in)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			for group, vals := range expected {
+				sameValidations(t, vals, out[group])
+			}
+		})
+	}
+
+	t.Run("tags", func(t *testing.T) {
+		vals, err := internal.ParseValidations(raw(`validate:"len=0|iscolor,name"`))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var got []string
+		for _, alts := range vals {
+			got = append(got, alts.Tag()+" "+alts.ActualTag())
+		}
+
+		if expected := []string{"len|iscolor len|hexcolor|rgb", "name required", "name max"}; !slices.Equal(expected, got) {
+			t.Errorf("expected tags %q, got %q", expected, got)
+		}
+	})
+
+	for in, msg := range map[string]string{
+		raw(`validate:"name|len=0"`): `alias "name" of multiple rules can not be one of the alternatives`,
+		raw(`validate:"iscolor=1"`):  `alias "iscolor" has no parameter`,
+	} {
+		t.Run(in, func(t *testing.T) {
+			_, err := internal.ParseGroups(in)
+			if err == nil || !strings.Contains(err.Error(), msg) {
+				t.Errorf("expected error %q, got: %v", msg, err)
 			}
+		})
+	}
+}
+
+func Test_Message(t *testing.T) {
+	internal.Log = newTestLog(t)
+
+	tag := raw(`validate:"required,eqfield=Other,gte=Other" msg:"{field} is invalid" msg.required:"{field} is required"`)
+	vals, err := internal.ParseValidations(tag)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	msgs, err := internal.ParseMessages(tag, vals)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	str := internal.Struct{Name: "User", Templates: internal.Messages{internal.Gte: "{field} is too small"}}
+	field := internal.Field{Name: "Name", Validations: vals, Messages: msgs}
+	cases := map[string]string{
+		internal.Required: "{field} is required",
+		internal.Eqfield:  "{field} is invalid",
+		internal.Gte:      "{field} is invalid",
+		"unknown":         "{field} is invalid",
+	}
+
+	for key, expected := range cases {
+		if got := internal.Message(key, str, field); got != expected {
+			t.Errorf("key %q: expected message %q, got %q", key, expected, got)
 		}
 	}
+
+	field.Messages = nil
+	if got := internal.Message(internal.Gte, str, field); got != "{field} is too small" {
+		t.Errorf("expected message from templates, got %q", got)
+	}
+
+	if got := internal.Message(internal.Eqfield, str, field); got != internal.DefaultMessages[internal.Eqfield] {
+		t.Errorf("expected default message, got %q", got)
+	}
+}
+
+func Test_GenerateValidation_Length(t *testing.T) {
+	internal.Log = newTestLog(t)
+
+	str := internal.Struct{Name: "Box"}
+	cases := map[string]struct {
+		rule    internal.Rule
+		typ     internal.Type
+		cond    string
+		imports []string
+	}{
+		"min of string counts runes": {internal.Rule{Name: internal.Min, Param: "3"}, "string", "if utf8.RuneCountInString(b.V) < 3 {", []string{"unicode/utf8"}},
+		"len of slice":               {internal.Rule{Name: internal.Len, Param: "2"}, "[]int", "if len(b.V) != 2 {", nil},
+		"min of map":                 {internal.Rule{Name: internal.Min, Param: "1"}, "map[string]int", "if len(b.V) < 1 {", nil},
+		"max of integer":             {internal.Rule{Name: internal.Max, Param: "10"}, "uint8", "if b.V > 10 {", nil},
+		"min of float":               {internal.Rule{Name: internal.Min, Param: "0.5"}, "float64", "if !(b.V >= 0.5) {", nil},
+		"min of signed integer":      {internal.Rule{Name: internal.Min, Param: "-1"}, "int8", "if b.V < -1 {", nil},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			stmt, imports, err := internal.GenerateValidation(internal.Alternatives{c.rule}, str, internal.Field{Name: "V", Type: c.typ})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			out := &bytes.Buffer{}
+			if err := format.Node(out, token.NewFileSet(), stmt); err != nil {
+				t.Fatalf("formatting statement: %v", err)
+			}
+
+			if !strings.HasPrefix(out.String(), c.cond) {
+				t.Errorf("expected statement starting with %q, got:\n%s", c.cond, out)
+			}
+
+			for _, imp := range c.imports {
+				if !slices.Contains(imports, imp) {
+					t.Errorf("expected import %q, got: %q", imp, imports)
+				}
+			}
+		})
+	}
+
+	errs := map[string]struct {
+		rule internal.Rule
+		typ  internal.Type
+	}{
+		`validation "min" expects non-negative integer, got: "1.5"`:  {internal.Rule{Name: internal.Min, Param: "1.5"}, "string"},
+		`validation "len" expects non-negative integer, got: "x"`:    {internal.Rule{Name: internal.Len, Param: "x"}, "[]int"},
+		`validation "min" expects non-negative integer, got: "-1"`:   {internal.Rule{Name: internal.Min, Param: "-1"}, "string"},
+		`validation "max" expects non-negative integer, got: "-1"`:   {internal.Rule{Name: internal.Max, Param: "-1"}, "map[string]int"},
+		`validation "min" value -1 overflows type "uint"`:            {internal.Rule{Name: internal.Min, Param: "-1"}, "uint"},
+		`validation "max" value 300 overflows type "uint8"`:          {internal.Rule{Name: internal.Max, Param: "300"}, "uint8"},
+		`validation "max" value 1e39 overflows type "float32"`:       {internal.Rule{Name: internal.Max, Param: "1e39"}, "float32"},
+		`validation "max" expects number, got: "x"`:                  {internal.Rule{Name: internal.Max, Param: "x"}, "float32"},
+		`validation "min" expects exactly 1 option, but got: 0 - ""`: {internal.Rule{Name: internal.Min}, "int"},
+		`unsupported type for validation: "max", type: "*string"`:    {internal.Rule{Name: internal.Max, Param: "1"}, "*string"},
+		`unsupported type for validation: "max", type: "bool"`:       {internal.Rule{Name: internal.Max, Param: "1"}, "bool"},
+	}
+
+	for msg, c := range errs {
+		t.Run(msg, func(t *testing.T) {
+			_, _, err := internal.GenerateValidation(internal.Alternatives{c.rule}, str, internal.Field{Name: "V", Type: c.typ})
+			if err == nil || !strings.Contains(err.Error(), msg) {
+				t.Errorf("expected error %q, got: %v", msg, err)
+			}
+		})
+	}
+}
+
+func sameValidations(t *testing.T, expected, got internal.Validations) {
+	t.Helper()
+	sameRule := func(a, b internal.Rule) bool { return a.Name == b.Name && a.Param == b.Param }
+	sameAlts := func(a, b internal.Alternatives) bool { return slices.EqualFunc(a, b, sameRule) }
+	if !slices.EqualFunc(expected, got, sameAlts) {
+		t.Errorf("expected validations %q, got %q", expected, got)
+	}
 }
 
 func raw(s string) string {
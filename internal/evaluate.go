@@ -0,0 +1,336 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/paluszkiewiczB/validator/validation"
+)
+
+// Evaluator evaluates the rule at runtime, with the same semantics as the code of its Generator.
+type Evaluator interface {
+	// Evaluate returns true, when the value of the field satisfies the rule. The str is the value of the struct
+	// with the field, rules like eqfield read the other fields from it.
+	Evaluate(rule Rule, value, str reflect.Value) (bool, error)
+}
+
+type EvaluatorFunc func(rule Rule, value, str reflect.Value) (bool, error)
+
+func (f EvaluatorFunc) Evaluate(rule Rule, value, str reflect.Value) (bool, error) {
+	return f(rule, value, str)
+}
+
+func EvaluatorFor(validation string) Evaluator {
+	v, ok := validators[validation]
+	if !ok {
+		return nil
+	}
+
+	return v.Evaluator
+}
+
+// EvaluateValidation returns validation.FieldError, when the value of the field does not satisfy any of the alternatives,
+// like the statement of GenerateValidation. The rules are checked with the generators first, so it rejects
+// exactly the rules, which the generator rejects.
+func EvaluateValidation(alts Alternatives, str Struct, field Field, value, strValue reflect.Value) error {
+	for _, rule := range alts {
+		gen, eval := GeneratorFor(rule.Name), EvaluatorFor(rule.Name)
+		if gen == nil || eval == nil {
+			return fmt.Errorf("validator not found for struct: %q, field: %q, validation: %q", str.Name, field.Name, rule.Name)
+		}
+
+		if _, err := gen.Generate(rule, str, field); err != nil {
+			return err
+		}
+	}
+
+	for _, rule := range alts {
+		ok, err := EvaluatorFor(rule.Name).Evaluate(rule, value, strValue)
+		if err != nil {
+			return fmt.Errorf("struct: %q, field: %q, validation: %q, %w", str.Name, field.Name, rule.Name, err)
+		}
+
+		if ok {
+			return nil
+		}
+	}
+
+	return fieldError(alts, str, field, value.Interface())
+}
+
+// EvaluateStruct returns *validation.FieldError of the first validations of the group, which the value of the struct
+// violates, like the generated method ValidateGroup, or validation.FieldErrors of the first violated validations
+// of every field with collectAll. The elements of the dive are validated only, when the field satisfies its rules,
+// like in the generated loop.
+func EvaluateStruct(str Struct, group string, value reflect.Value, collectAll bool) error {
+	var errs validation.FieldErrors
+	for _, field := range str.Fields {
+		field = field.InGroup(group)
+		vals, elems, dive := field.Validations.Dive()
+		field.Validations = vals
+		fieldValue := value.FieldByName(field.Name)
+		fieldErr, err := evaluateField(str, field, fieldValue, value, collectAll)
+		if err != nil {
+			return err
+		}
+
+		if fieldErr != nil {
+			errs = append(errs, fieldErr)
+			continue
+		}
+
+		if !dive {
+			continue
+		}
+
+		elem := field.Elem(elems)
+		for idx := range fieldValue.Len() {
+			fieldErr, err := evaluateField(str, elem, fieldValue.Index(idx), value, collectAll)
+			if err != nil {
+				return indexedErr(err, idx)
+			}
+
+			if fieldErr != nil {
+				errs = append(errs, indexedErr(fieldErr, idx).(*validation.FieldError))
+			}
+		}
+	}
+
+	if len(errs) != 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// evaluateField returns the error of the first violated validations of the field. With collectAll,
+// the *validation.FieldError is returned as the first value, so it can be collected.
+func evaluateField(str Struct, field Field, value, strValue reflect.Value, collectAll bool) (*validation.FieldError, error) {
+	for _, alts := range field.Validations {
+		err := EvaluateValidation(alts, str, field, value, strValue)
+		if err == nil {
+			continue
+		}
+
+		var fieldErr *validation.FieldError
+		if !collectAll || !errors.As(err, &fieldErr) {
+			return nil, err
+		}
+
+		return fieldErr, nil
+	}
+
+	return nil, nil
+}
+
+// indexedErr returns the error of the element with the index, e.g. 'tags[1]', like the generated loop of dive reports it.
+func indexedErr(err error, idx int) error {
+	var fieldErr *validation.FieldError
+	if !errors.As(err, &fieldErr) {
+		return err
+	}
+
+	elemErr := *fieldErr
+	elemErr.Field = fmt.Sprintf("%s[%d]", fieldErr.Field, idx)
+	elemErr.StructField = fmt.Sprintf("%s[%d]", fieldErr.StructField, idx)
+	return &elemErr
+}
+
+// fieldError returns the error built by errorBlock, with the message formatted at runtime.
+func fieldError(alts Alternatives, str Struct, field Field, value any) *validation.FieldError {
+	param := ""
+	if len(alts) == 1 {
+		param = alts[0].Param
+	}
+
+	msg := resolveField(alternativesMessage(alts, str, field), field)
+	return &validation.FieldError{
+		Struct:      str.Name,
+		Field:       field.Reported(),
+		StructField: field.Name,
+		Tag:         alts.Tag(),
+		ActualTag:   alts.ActualTag(),
+		Param:       param,
+		Value:       value,
+		Message:     strings.ReplaceAll(msg, PlaceholderValue, fmt.Sprint(value)),
+	}
+}
+
+func evalRequired(_ Rule, value, _ reflect.Value) (bool, error) {
+	switch value.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map:
+		return value.Len() != 0, nil
+	case reflect.Pointer:
+		return !value.IsNil(), nil
+	}
+
+	return false, fmt.Errorf("unsupported kind: %s", value.Kind())
+}
+
+func evalEqfield(rule Rule, value, str reflect.Value) (bool, error) {
+	other, err := otherField(rule, str)
+	if err != nil {
+		return false, err
+	}
+
+	if value.Type() != other.Type() {
+		return false, fmt.Errorf("mismatched types: %s and %s", value.Type(), other.Type())
+	}
+
+	return value.Equal(other), nil
+}
+
+// evalGte converts both the fields to float64, like gte.
+func evalGte(rule Rule, value, str reflect.Value) (bool, error) {
+	other, err := otherField(rule, str)
+	if err != nil {
+		return false, err
+	}
+
+	x, err := toFloat64(value)
+	if err != nil {
+		return false, err
+	}
+
+	y, err := toFloat64(other)
+	if err != nil {
+		return false, err
+	}
+
+	return x >= y, nil
+}
+
+// evalLength returns the evaluator comparing the param with the length of strings (in runes), slices and maps,
+// or with the value of numbers, like length.
+func evalLength(op token.Token) EvaluatorFunc {
+	return func(rule Rule, value, _ reflect.Value) (bool, error) {
+		switch {
+		case value.Kind() == reflect.String:
+			n, err := strconv.Atoi(rule.Param)
+			return compare(op, utf8.RuneCountInString(value.String()), n), err
+		case value.Kind() == reflect.Slice, value.Kind() == reflect.Map:
+			n, err := strconv.Atoi(rule.Param)
+			return compare(op, value.Len(), n), err
+		case value.CanInt():
+			n, err := strconv.ParseInt(rule.Param, 10, 64)
+			return compare(op, value.Int(), n), err
+		case value.CanUint():
+			n, err := strconv.ParseUint(rule.Param, 10, 64)
+			return compare(op, value.Uint(), n), err
+		case value.CanFloat():
+			// the param is the constant of the type of the field
+			n, err := strconv.ParseFloat(rule.Param, value.Type().Bits())
+			return compare(op, value.Float(), n), err
+		}
+
+		return false, fmt.Errorf("unsupported kind: %s", value.Kind())
+	}
+}
+
+// evalOneof compares the string or the integer with each of the values of the param, like oneof.
+func evalOneof(rule Rule, value, _ reflect.Value) (bool, error) {
+	for _, v := range oneofValues(rule.Param) {
+		switch {
+		case value.Kind() == reflect.String:
+			if value.String() == v {
+				return true, nil
+			}
+		case value.CanInt():
+			n, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return false, err
+			}
+
+			if value.Int() == n {
+				return true, nil
+			}
+		case value.CanUint():
+			n, err := strconv.ParseUint(v, 10, 64)
+			if err != nil {
+				return false, err
+			}
+
+			if value.Uint() == n {
+				return true, nil
+			}
+		default:
+			return false, fmt.Errorf("unsupported kind: %s", value.Kind())
+		}
+	}
+
+	return false, nil
+}
+
+func evalRegexp(rule Rule, value, _ reflect.Value) (bool, error) {
+	if value.Kind() != reflect.String {
+		return false, fmt.Errorf("unsupported kind: %s", value.Kind())
+	}
+
+	return validation.MatchString(rule.Param, value.String()), nil
+}
+
+func evalEmail(_ Rule, value, _ reflect.Value) (bool, error) {
+	if value.Kind() != reflect.String {
+		return false, fmt.Errorf("unsupported kind: %s", value.Kind())
+	}
+
+	return validation.IsEmail(value.String()), nil
+}
+
+func evalURL(_ Rule, value, _ reflect.Value) (bool, error) {
+	if value.Kind() != reflect.String {
+		return false, fmt.Errorf("unsupported kind: %s", value.Kind())
+	}
+
+	return validation.IsURL(value.String()), nil
+}
+
+// evalDive is satisfied by any slice, like dive.
+func evalDive(_ Rule, value, _ reflect.Value) (bool, error) {
+	if value.Kind() != reflect.Slice {
+		return false, fmt.Errorf("unsupported kind: %s", value.Kind())
+	}
+
+	return true, nil
+}
+
+func compare[T int | int64 | uint64 | float64](op token.Token, x, y T) bool {
+	switch op {
+	case token.GEQ:
+		return x >= y
+	case token.LEQ:
+		return x <= y
+	case token.EQL:
+		return x == y
+	}
+
+	panic("unsupported operator: " + op.String())
+}
+
+// otherField returns the field of the struct named by the param of the rule.
+func otherField(rule Rule, str reflect.Value) (reflect.Value, error) {
+	other := str.FieldByName(rule.Param)
+	if !other.IsValid() {
+		return reflect.Value{}, fmt.Errorf("field %q not found in %s", rule.Param, str.Type())
+	}
+
+	return other, nil
+}
+
+func toFloat64(v reflect.Value) (float64, error) {
+	switch {
+	case v.CanInt():
+		return float64(v.Int()), nil
+	case v.CanUint():
+		return float64(v.Uint()), nil
+	case v.CanFloat():
+		return v.Float(), nil
+	}
+
+	return 0, fmt.Errorf("unsupported kind: %s", v.Kind())
+}
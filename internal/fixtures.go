@@ -0,0 +1,720 @@
+package internal
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"go/token"
+	"math"
+	"reflect"
+	"regexp/syntax"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/exp/maps"
+
+	"github.com/paluszkiewiczB/validator/validation"
+)
+
+// GenerateTests generates the formatted source of the test file in the package pkg. For every struct and every
+// validation group, it generates the function returning the minimal valid instance and the test, which checks
+// the instance is valid and that the mutation of a single field, or of the first element of the dive, breaking
+// each rule makes Validate, or ValidateGroup, return validation.FieldError of the rule.
+// The instances are built from the values of the types of the fields and checked with EvaluateStruct, the same
+// evaluators the package interpret validates with. Structs and groups, whose rules can not be evaluated,
+// and rules, which can not be broken without breaking the previous ones, are skipped with the comment.
+func GenerateTests(structs []Struct, pkg string) ([]byte, error) {
+	out := &bytes.Buffer{}
+	out.WriteString(header)
+	fmt.Fprintf(out, "package %s\n\n", pkg)
+	out.WriteString("import (\n\t\"errors\"\n\t\"testing\"\n\n\t\"" + ValidationPkg + "\"\n)\n")
+	for _, str := range structs {
+		if len(str.TypeParams) != 0 {
+			fmt.Fprintf(out, "\n// %s: tests skipped, generic struct can not be instantiated without the type arguments\n", str.Name)
+			continue
+		}
+
+		for _, group := range append([]string{DefaultGroup}, str.Groups()...) {
+			if err := writeTests(out, str, group); err != nil && group == DefaultGroup {
+				fmt.Fprintf(out, "\n// %s: tests skipped, %s\n", str.Name, err)
+			} else if err != nil {
+				fmt.Fprintf(out, "\n// %s: tests of group %q skipped, %s\n", str.Name, group, err)
+			}
+		}
+	}
+
+	formatted, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting tests: %w", err)
+	}
+
+	return formatted, nil
+}
+
+// FixtureFunc returns the name of the function returning the minimal valid instance of the struct in the group,
+// e.g. 'fixtureUser' for the DefaultGroup and 'fixtureUserCreate' for the group 'create'.
+func FixtureFunc(str Struct, group string) string {
+	return "fixture" + str.Name + groupSuffix(group)
+}
+
+// groupSuffix returns the group in the form of the suffix of the identifier, with the first letter in upper case
+// and the characters, which are not allowed in the identifiers, replaced with '_'.
+func groupSuffix(group string) string {
+	suffix := []rune(group)
+	for i, r := range suffix {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			suffix[i] = '_'
+		}
+	}
+
+	if len(suffix) != 0 {
+		suffix[0] = unicode.ToUpper(suffix[0])
+	}
+
+	return string(suffix)
+}
+
+func writeTests(out *bytes.Buffer, str Struct, group string) error {
+	valid, err := validFixture(str, group)
+	if err != nil {
+		return err
+	}
+
+	validate := ValidateMethod(str) + "()"
+	if group != DefaultGroup {
+		validate = fmt.Sprintf("%s(%q)", ValidateGroupMethod(str), group)
+	}
+
+	types := fieldTypes(str)
+	body := &bytes.Buffer{}
+	fmt.Fprintf(body, "\nfunc %s() %s {\n\treturn %s{\n", FixtureFunc(str, group), str.Name, str.Name)
+	for _, name := range fixtureFields(str) {
+		lit, err := fixtureLiteral(types[name], valid.FieldByName(name))
+		if err != nil {
+			return fmt.Errorf("field: %q, %w", name, err)
+		}
+
+		if lit != "" {
+			fmt.Fprintf(body, "\t\t%s: %s,\n", name, lit)
+		}
+	}
+	body.WriteString("\t}\n}\n")
+
+	test := "Validate"
+	if group != DefaultGroup {
+		test = "ValidateGroup" + groupSuffix(group)
+	}
+	fmt.Fprintf(body, "\nfunc Test_%s_%s(t *testing.T) {\n", str.Name, test)
+	fmt.Fprintf(body, "\tt.Run(\"valid\", func(t *testing.T) {\n\t\tv := %s()\n\t\tif err := v.%s; err != nil {\n", FixtureFunc(str, group), validate)
+	body.WriteString("\t\t\tt.Errorf(\"expected no error, got: %v\", err)\n\t\t}\n\t})\n")
+	fmt.Fprintf(body, "\n\tcases := []struct {\n\t\tname   string\n\t\tmutate func(v *%s)\n\t\tfield  string\n\t\ttag    string\n\t}{\n", str.Name)
+	for _, field := range str.Fields {
+		field = field.InGroup(group)
+		vals, elems, dive := field.Validations.Dive()
+		field.Validations = vals
+		cases := []fixtureCase{{field: field, target: field.Name}}
+		if dive && valid.FieldByName(field.Name).Len() != 0 {
+			cases = append(cases, fixtureCase{field: field.Elem(elems), target: field.Name + "[0]", elem: true})
+		}
+
+		for _, c := range cases {
+			for _, alts := range c.field.Validations {
+				name := c.target + " " + alts.String()
+				value, ok, err := breaking(str, group, valid, c, alts)
+				if err != nil {
+					return err
+				}
+
+				if !ok {
+					fmt.Fprintf(body, "\t\t// %s: skipped, it can not be broken alone\n", name)
+					continue
+				}
+
+				lit, err := fixtureLiteral(c.field.Type, value)
+				if err != nil {
+					return fmt.Errorf("field: %q, %w", field.Name, err)
+				}
+
+				if lit == "" {
+					lit = zeroLiteral(c.field.Type)
+				}
+
+				fmt.Fprintf(body, "\t\t{name: %q, mutate: func(v *%s) { v.%s = %s }, field: %q, tag: %q},\n", name, str.Name, c.target, lit, c.target, alts.Tag())
+			}
+		}
+	}
+	body.WriteString("\t}\n\n\tfor _, c := range cases {\n\t\tt.Run(c.name, func(t *testing.T) {\n")
+	fmt.Fprintf(body, "\t\t\tv := %s()\n\t\t\tc.mutate(&v)\n\n", FixtureFunc(str, group))
+	body.WriteString("\t\t\tvar fieldErr *validation.FieldError\n")
+	fmt.Fprintf(body, "\t\t\tif err := v.%s; !errors.As(err, &fieldErr) || fieldErr.StructField != c.field || fieldErr.Tag != c.tag {\n", validate)
+	body.WriteString("\t\t\t\tt.Errorf(\"expected error of field %q and tag %q, got: %v\", c.field, c.tag, err)\n\t\t\t}\n\t\t})\n\t}\n}\n")
+
+	out.Write(body.Bytes())
+	return nil
+}
+
+// fixtureCase is the field, or the first element of the dive, mutated by the test case.
+type fixtureCase struct {
+	field Field
+	// target is the mutated expression and the StructField of the expected error, e.g. 'Tags[0]'.
+	target string
+	elem   bool
+}
+
+// fixtureFields returns the names of the validated fields and of the fields their rules refer to, e.g. by eqfield.
+func fixtureFields(str Struct) []string {
+	var names []string
+	for _, field := range str.Fields {
+		names = append(names, field.Name)
+		for _, vals := range append(maps.Values(field.Groups), field.Validations) {
+			for _, alts := range vals {
+				for _, rule := range alts {
+					if rule.Name == Eqfield || rule.Name == Gte {
+						names = append(names, rule.Param)
+					}
+				}
+			}
+		}
+	}
+
+	var unique []string
+	for _, name := range names {
+		if !slices.Contains(unique, name) {
+			unique = append(unique, name)
+		}
+	}
+
+	return unique
+}
+
+// fixtureType returns the struct type with the fixtureFields of the struct, so the fixture can be evaluated
+// with EvaluateStruct. The fields of the types, which are not relevant for the rules, e.g. the elements of the slices
+// of structs, are replaced with struct{}.
+func fixtureType(str Struct) (reflect.Type, error) {
+	types := fieldTypes(str)
+	var fields []reflect.StructField
+	for _, name := range fixtureFields(str) {
+		t, ok := types[name]
+		if !ok {
+			return nil, fmt.Errorf("field %q not found", name)
+		}
+
+		if !token.IsExported(name) {
+			return nil, fmt.Errorf("field: %q, unexported field can not be instantiated", name)
+		}
+
+		rt, err := reflectType(t)
+		if err != nil {
+			return nil, fmt.Errorf("field: %q, %w", name, err)
+		}
+
+		fields = append(fields, reflect.StructField{Name: name, Type: rt})
+	}
+
+	return reflect.StructOf(fields), nil
+}
+
+// basicTypes are the reflect types of the predeclared types of the fields.
+var basicTypes = map[Type]reflect.Type{
+	"string":  reflect.TypeFor[string](),
+	"bool":    reflect.TypeFor[bool](),
+	"byte":    reflect.TypeFor[byte](),
+	"rune":    reflect.TypeFor[rune](),
+	"int":     reflect.TypeFor[int](),
+	"int8":    reflect.TypeFor[int8](),
+	"int16":   reflect.TypeFor[int16](),
+	"int32":   reflect.TypeFor[int32](),
+	"int64":   reflect.TypeFor[int64](),
+	"uint":    reflect.TypeFor[uint](),
+	"uint8":   reflect.TypeFor[uint8](),
+	"uint16":  reflect.TypeFor[uint16](),
+	"uint32":  reflect.TypeFor[uint32](),
+	"uint64":  reflect.TypeFor[uint64](),
+	"uintptr": reflect.TypeFor[uintptr](),
+	"float32": reflect.TypeFor[float32](),
+	"float64": reflect.TypeFor[float64](),
+}
+
+// reflectType returns the reflect type of the field of the type. Pointers, slices and maps of other types
+// are built of struct{}, as only their nil-ness or length is validated.
+func reflectType(t Type) (reflect.Type, error) {
+	if rt, ok := basicTypes[t]; ok {
+		return rt, nil
+	}
+
+	elem := func() reflect.Type {
+		if rt, err := reflectType(t.Elem()); err == nil {
+			return rt
+		}
+		return reflect.TypeFor[struct{}]()
+	}
+
+	switch {
+	case t.IsPtr():
+		if rt, ok := basicTypes[t[1:]]; ok {
+			return reflect.PointerTo(rt), nil
+		}
+		return reflect.TypeFor[*struct{}](), nil
+	case t.IsSlice():
+		return reflect.SliceOf(elem()), nil
+	case t.IsMap():
+		key := mapKey(t)
+		if !key.IsString() && !key.IsInteger() {
+			return nil, fmt.Errorf("unsupported key of map: %q", t)
+		}
+		return reflect.MapOf(basicTypes[key], elem()), nil
+	}
+
+	return nil, fmt.Errorf("unsupported type: %q", t)
+}
+
+// mapKey returns the type of the keys of the map type.
+func mapKey(t Type) Type {
+	return Type(strings.TrimSuffix(strings.TrimPrefix(string(t), "map["), "]"+string(t.Elem())))
+}
+
+// validFixture returns the instance of the fixtureType satisfying all the rules of the group.
+// Every violated validations of the field are satisfied with the first candidate value of their rules,
+// which satisfies the previous validations of the field too.
+func validFixture(str Struct, group string) (reflect.Value, error) {
+	t, err := fixtureType(str)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	fixture := reflect.New(t).Elem()
+	// rules referring to the other fields may depend on the fields adjusted later
+	for range 2 {
+		for _, field := range str.Fields {
+			field = field.InGroup(group)
+			vals, elems, dive := field.Validations.Dive()
+			field.Validations = vals
+			value := fixture.FieldByName(field.Name)
+			if err := satisfy(str, field, value, fixture); err != nil {
+				return reflect.Value{}, err
+			}
+
+			if !dive {
+				continue
+			}
+
+			for idx := range value.Len() {
+				if err := satisfy(str, field.Elem(elems), value.Index(idx), fixture); err != nil {
+					return reflect.Value{}, err
+				}
+			}
+		}
+	}
+
+	err = EvaluateStruct(str, group, fixture, false)
+	var fieldErr *validation.FieldError
+	if errors.As(err, &fieldErr) {
+		return reflect.Value{}, fmt.Errorf("no valid instance found, field %q is invalid", fieldErr.StructField)
+	}
+
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	return fixture, nil
+}
+
+// satisfy sets the value of the field to the candidate satisfying its violated validations.
+func satisfy(str Struct, field Field, value, fixture reflect.Value) error {
+	for i, alts := range field.Validations {
+		ok, err := satisfies(str, field, Validations{alts}, value, fixture)
+		if err != nil {
+			return err
+		}
+
+		if ok {
+			continue
+		}
+
+		var chosen, fallback reflect.Value
+		for _, rule := range alts {
+			for _, c := range satisfying(value.Type(), rule, fixture) {
+				if ok, _ := satisfies(str, field, field.Validations[:i+1], c, fixture); ok && !chosen.IsValid() {
+					chosen = c
+				}
+
+				if ok, _ := satisfies(str, field, Validations{alts}, c, fixture); ok && !fallback.IsValid() {
+					fallback = c
+				}
+			}
+		}
+
+		if chosen.IsValid() {
+			value.Set(chosen)
+			continue
+		}
+
+		// the rules comparing the field with the other one are satisfied by the other field equal to the field
+		if other, ok := comparedField(alts, value, fixture); ok {
+			other.Set(value)
+			continue
+		}
+
+		if fallback.IsValid() {
+			value.Set(fallback)
+		}
+	}
+
+	return nil
+}
+
+// comparedField returns the other field of the fixture of the same type as the value, which eqfield or gte
+// of the alternatives compares the value with.
+func comparedField(alts Alternatives, value, fixture reflect.Value) (reflect.Value, bool) {
+	for _, rule := range alts {
+		if rule.Name != Eqfield && rule.Name != Gte {
+			continue
+		}
+
+		if other := fixture.FieldByName(rule.Param); other.IsValid() && other.Type() == value.Type() {
+			return other, true
+		}
+	}
+
+	return reflect.Value{}, false
+}
+
+// satisfies returns true, when the value of the field satisfies all the validations, according to EvaluateValidation.
+func satisfies(str Struct, field Field, vals Validations, value, fixture reflect.Value) (bool, error) {
+	for _, alts := range vals {
+		err := EvaluateValidation(alts, str, field, value, fixture)
+		var fieldErr *validation.FieldError
+		if errors.As(err, &fieldErr) {
+			return false, nil
+		}
+
+		if err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// breaking returns the value of the field, or of the first element of the dive, which violates the alternatives,
+// while the other rules are satisfied, according to EvaluateStruct.
+func breaking(str Struct, group string, valid reflect.Value, c fixtureCase, alts Alternatives) (reflect.Value, bool, error) {
+	target := valid.FieldByName(c.field.Name)
+	if c.elem {
+		target = target.Index(0)
+	}
+
+	for _, rule := range alts {
+		for _, candidate := range violating(target.Type(), rule, valid) {
+			mutated := reflect.New(valid.Type()).Elem()
+			mutated.Set(valid)
+			value := mutated.FieldByName(c.field.Name)
+			if c.elem {
+				value.Set(reflect.AppendSlice(reflect.MakeSlice(value.Type(), 0, value.Len()), value))
+				value = value.Index(0)
+			}
+			value.Set(candidate)
+
+			err := EvaluateStruct(str, group, mutated, false)
+			var fieldErr *validation.FieldError
+			if errors.As(err, &fieldErr) && fieldErr.StructField == c.target && fieldErr.Tag == alts.Tag() {
+				return candidate, true, nil
+			}
+
+			if err != nil && fieldErr == nil {
+				return reflect.Value{}, false, err
+			}
+		}
+	}
+
+	return reflect.Value{}, false, nil
+}
+
+// satisfying returns the values of the type, which may satisfy the rule, in the order of preference.
+func satisfying(t reflect.Type, rule Rule, fixture reflect.Value) []reflect.Value {
+	n, _ := strconv.ParseFloat(rule.Param, 64)
+	var out []reflect.Value
+	switch rule.Name {
+	case Required:
+		if t.Kind() == reflect.Pointer {
+			return []reflect.Value{reflect.New(t.Elem())}
+		}
+		out = append(out, sized(t, 1))
+	case Min, Max, Len:
+		out = append(out, sized(t, n))
+	case Eqfield:
+		out = append(out, converted(t, fixture.FieldByName(rule.Param), 0))
+	case Gte:
+		f, err := toFloat64(fixture.FieldByName(rule.Param))
+		if err != nil {
+			break
+		}
+
+		if t.Kind() != reflect.Float32 && t.Kind() != reflect.Float64 {
+			f = math.Ceil(f)
+		}
+		out = append(out, sized(t, f))
+	case Oneof:
+		for _, v := range oneofValues(rule.Param) {
+			out = append(out, parsed(t, v))
+		}
+	case Email:
+		out = append(out, parsed(t, "a@example.com"))
+	case URL:
+		out = append(out, parsed(t, "https://example.com"))
+	case Regexp:
+		if s, ok := regexpSample(rule.Param); ok {
+			out = append(out, parsed(t, s))
+		}
+	}
+
+	return slices.DeleteFunc(out, func(v reflect.Value) bool { return !v.IsValid() })
+}
+
+// violating returns the values of the type, which may violate the rule, in the order of preference.
+func violating(t reflect.Type, rule Rule, fixture reflect.Value) []reflect.Value {
+	n, _ := strconv.ParseFloat(rule.Param, 64)
+	var out []reflect.Value
+	switch rule.Name {
+	case Required:
+		out = append(out, reflect.Zero(t))
+	case Min:
+		out = append(out, sized(t, n-1))
+	case Max, Len:
+		out = append(out, sized(t, n+1))
+	case Eqfield:
+		out = append(out, converted(t, fixture.FieldByName(rule.Param), 1))
+	case Gte:
+		out = append(out, converted(t, fixture.FieldByName(rule.Param), -1))
+	case Oneof:
+		out = append(out, reflect.Zero(t))
+		for _, v := range oneofValues(rule.Param) {
+			if t.Kind() == reflect.String {
+				out = append(out, parsed(t, v+"x"))
+				continue
+			}
+
+			f, _ := strconv.ParseFloat(v, 64)
+			out = append(out, sized(t, f+1), sized(t, f-1))
+		}
+	case Email, URL, Regexp:
+		out = append(out, reflect.Zero(t), parsed(t, " "))
+	}
+
+	return slices.DeleteFunc(out, func(v reflect.Value) bool { return !v.IsValid() })
+}
+
+// sized returns the value of the type with the length, or the number n: strings are n times 'a', slices and maps
+// have n zero elements. It returns the invalid value, when n can not be represented by the type.
+func sized(t reflect.Type, n float64) reflect.Value {
+	v := reflect.New(t).Elem()
+	length := int(n)
+	switch {
+	case t.Kind() == reflect.String, t.Kind() == reflect.Slice, t.Kind() == reflect.Map:
+		if n < 0 || n != math.Trunc(n) {
+			return reflect.Value{}
+		}
+	case v.CanInt():
+		if n != math.Trunc(n) || n < math.MinInt64 || n >= math.MaxInt64 || v.OverflowInt(int64(n)) {
+			return reflect.Value{}
+		}
+		v.SetInt(int64(n))
+		return v
+	case v.CanUint():
+		if n < 0 || n != math.Trunc(n) || n >= math.MaxUint64 || v.OverflowUint(uint64(n)) {
+			return reflect.Value{}
+		}
+		v.SetUint(uint64(n))
+		return v
+	case v.CanFloat():
+		if v.OverflowFloat(n) {
+			return reflect.Value{}
+		}
+		v.SetFloat(n)
+		return v
+	default:
+		return reflect.Value{}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		v.SetString(strings.Repeat("a", length))
+	case reflect.Slice:
+		v.Set(reflect.MakeSlice(t, length, length))
+	case reflect.Map:
+		v.Set(reflect.MakeMapWithSize(t, length))
+		for i := range length {
+			key := reflect.New(t.Key()).Elem()
+			if key.Kind() == reflect.String {
+				key.SetString(strconv.Itoa(i))
+			} else if k := sized(t.Key(), float64(i)); k.IsValid() {
+				key = k
+			}
+			v.SetMapIndex(key, reflect.Zero(t.Elem()))
+		}
+	}
+
+	return v
+}
+
+// converted returns the value of the other field converted to the type, with the delta added to numbers
+// and 'b' appended to strings for the non-zero delta. It returns the invalid value for other types.
+func converted(t reflect.Type, other reflect.Value, delta float64) reflect.Value {
+	if !other.IsValid() {
+		return reflect.Value{}
+	}
+
+	if other.Kind() == reflect.String && t.Kind() == reflect.String {
+		s := other.String()
+		if delta != 0 {
+			s += "b"
+		}
+		return parsed(t, s)
+	}
+
+	f, err := toFloat64(other)
+	if err != nil {
+		return reflect.Value{}
+	}
+
+	return sized(t, f+delta)
+}
+
+// parsed returns the value of the type from the string, or the invalid value, when it is not the string
+// or the number of the type.
+func parsed(t reflect.Type, s string) reflect.Value {
+	if t.Kind() == reflect.String {
+		v := reflect.New(t).Elem()
+		v.SetString(s)
+		return v
+	}
+
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return reflect.Value{}
+	}
+
+	return sized(t, f)
+}
+
+// regexpSample returns the shortest string built of the first alternatives and characters of the pattern.
+// It may not match the pattern with the assertions, e.g. '\b', the candidates are checked by the evaluators anyway.
+func regexpSample(pattern string) (string, bool) {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return "", false
+	}
+
+	b := &strings.Builder{}
+	var write func(re *syntax.Regexp) bool
+	write = func(re *syntax.Regexp) bool {
+		switch re.Op {
+		case syntax.OpNoMatch:
+			return false
+		case syntax.OpLiteral:
+			b.WriteString(string(re.Rune))
+		case syntax.OpCharClass:
+			if len(re.Rune) == 0 {
+				return false
+			}
+			b.WriteRune(re.Rune[0])
+		case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+			b.WriteRune('a')
+		case syntax.OpCapture, syntax.OpPlus:
+			return write(re.Sub[0])
+		case syntax.OpRepeat:
+			for range re.Min {
+				if !write(re.Sub[0]) {
+					return false
+				}
+			}
+		case syntax.OpConcat:
+			for _, sub := range re.Sub {
+				if !write(sub) {
+					return false
+				}
+			}
+		case syntax.OpAlternate:
+			return write(re.Sub[0])
+		}
+
+		return true
+	}
+
+	ok := write(re)
+	return b.String(), ok
+}
+
+// fixtureLiteral returns the expression of the value of the field of the type, or empty string for the zero value.
+func fixtureLiteral(t Type, v reflect.Value) (string, error) {
+	if v.IsZero() || ((t.IsSlice() || t.IsMap()) && v.Len() == 0) {
+		return "", nil
+	}
+
+	switch {
+	case t.IsString():
+		return strconv.Quote(v.String()), nil
+	case t.IsBool():
+		return strconv.FormatBool(v.Bool()), nil
+	case t.IsInteger() && v.CanInt():
+		return strconv.FormatInt(v.Int(), 10), nil
+	case t.IsInteger():
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case t.IsNumber():
+		return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits()), nil
+	case t.IsPtr():
+		if !v.Elem().IsZero() {
+			return "", fmt.Errorf("unsupported value of pointer: %v", v.Elem())
+		}
+		return "new(" + string(t[1:]) + ")", nil
+	case t.IsSlice():
+		elems := make([]string, v.Len())
+		zero := true
+		for i := range elems {
+			lit, err := fixtureLiteral(t.Elem(), v.Index(i))
+			if err != nil {
+				return "", err
+			}
+
+			if lit == "" {
+				lit = zeroLiteral(t.Elem())
+			}
+			elems[i], zero = lit, zero && v.Index(i).IsZero()
+		}
+
+		if zero {
+			return fmt.Sprintf("make(%s, %d)", t, v.Len()), nil
+		}
+		return fmt.Sprintf("%s{%s}", t, strings.Join(elems, ", ")), nil
+	case t.IsMap():
+		key := mapKey(t)
+		entries := make([]string, v.Len())
+		for i := range entries {
+			k := strconv.Itoa(i)
+			if key.IsString() {
+				k = strconv.Quote(k)
+			}
+			entries[i] = fmt.Sprintf("%s: *new(%s)", k, t.Elem())
+		}
+		return fmt.Sprintf("%s{%s}", t, strings.Join(entries, ", ")), nil
+	}
+
+	return "", fmt.Errorf("unsupported type: %q", t)
+}
+
+// zeroLiteral returns the expression of the zero value of the type.
+func zeroLiteral(t Type) string {
+	switch {
+	case t.IsString():
+		return `""`
+	case t.IsNumber():
+		return "0"
+	case t.IsPtr(), t.IsSlice(), t.IsMap():
+		return "nil"
+	}
+
+	return "*new(" + string(t) + ")"
+}
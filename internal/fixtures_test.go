@@ -0,0 +1,65 @@
+package internal_test
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/paluszkiewiczB/validator/internal"
+)
+
+func Test_GenerateTests(t *testing.T) {
+	internal.Log = newTestLog(t)
+
+	src := "package example\n" +
+		"type User struct {\n" +
+		"	Name  string `validate:\"required,min=1,max=3\"`\n" +
+		"	Count uint   `validate:\"min=0\"`\n" +
+		"}\n" +
+		"type Unknown struct {\n" +
+		"	ID string `validate:\"uuid\"`\n" +
+		"}\n" +
+		"type Signup struct {\n" +
+		"	Password string   `validate:\"required\" validate.create:\"required,eqfield=Repeated\"`\n" +
+		"	Repeated string\n" +
+		"	Plan     string   `validate:\"oneof=free pro\"`\n" +
+		"	Tags     []string `validate:\"required,dive,max=2\"`\n" +
+		"}\n"
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "example.go", src, 0)
+	if err != nil {
+		t.Fatalf("parsing source: %v", err)
+	}
+
+	structs, err := internal.FindStructs(fset, f)
+	if err != nil {
+		t.Fatalf("finding structs: %v", err)
+	}
+
+	out, err := internal.GenerateTests(structs, "example")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, expected := range []string{
+		"// Unknown: tests skipped, validator not found for struct: \"Unknown\", field: \"ID\", validation: \"uuid\"\n",
+		"func fixtureUser() User {\n\treturn User{\n\t\tName: \"a\",\n\t}\n}\n",
+		`{name: "Name required", mutate: func(v *User) { v.Name = "" }, field: "Name", tag: "required"},`,
+		"// Name min=1: skipped, it can not be broken alone\n",
+		`{name: "Name max=3", mutate: func(v *User) { v.Name = "aaaa" }, field: "Name", tag: "max"},`,
+		"// Count min=0: skipped, it can not be broken alone\n",
+		"func fixtureSignup() Signup {\n\treturn Signup{\n\t\tPassword: \"a\",\n\t\tPlan:     \"free\",\n\t\tTags:     make([]string, 1),\n\t}\n}\n",
+		`{name: "Plan oneof=free pro", mutate: func(v *Signup) { v.Plan = "" }, field: "Plan", tag: "oneof"},`,
+		`{name: "Tags[0] max=2", mutate: func(v *Signup) { v.Tags[0] = "aaa" }, field: "Tags[0]", tag: "max"},`,
+		"func fixtureSignupCreate() Signup {\n\treturn Signup{\n\t\tPassword: \"a\",\n\t\tRepeated: \"a\",\n",
+		"func Test_Signup_ValidateGroupCreate(t *testing.T) {\n",
+		`{name: "Password eqfield=Repeated", mutate: func(v *Signup) { v.Password = "ab" }, field: "Password", tag: "eqfield"},`,
+		`if err := v.ValidateGroup("create"); !errors.As(err, &fieldErr)`,
+	} {
+		if !strings.Contains(string(out), expected) {
+			t.Errorf("expected output to contain:\n%s\ngot:\n%s", expected, out)
+		}
+	}
+}
@@ -0,0 +1,306 @@
+package internal
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/types"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// fuzzScalars are the types, which the native fuzzing accepts as the arguments.
+var fuzzScalars = []Type{
+	"string", "bool", "byte", "rune",
+	"int", "int8", "int16", "int32", "int64",
+	"uint", "uint8", "uint16", "uint32", "uint64",
+	"float32", "float64",
+}
+
+// fuzzRules are the rules interpreted by the fuzzOracle. The other rules call the same functions of the package
+// validation in the generated code and in the interpreter, so there is nothing to compare them with.
+var fuzzRules = []string{Required, Eqfield, Gte, Min, Max, Len}
+
+// fuzzMaxLen is the maximal length of the slices and maps built from the fuzz input.
+const fuzzMaxLen = 16
+
+// fuzzOracle is the function interpreting the rules independently of the generators, emitted into the fuzz file.
+// Numbers are compared exactly, so the conversions of the generated code can not hide the violations.
+const fuzzOracle = `
+// validatorSatisfies interprets the rule with the param for the value v, other is the field the rule refers to.
+// It is independent of the generated code: lengths and numbers are compared exactly with big.Float.
+func validatorSatisfies(v reflect.Value, rule, param string, other reflect.Value) bool {
+	switch rule {
+	case "required":
+		if v.Kind() == reflect.Pointer {
+			return !v.IsNil()
+		}
+		return v.Len() != 0
+	case "eqfield":
+		return v.Interface() == other.Interface()
+	}
+
+	x, ok := validatorExact(v)
+	if !ok {
+		return false
+	}
+
+	var y *big.Float
+	if rule == "gte" {
+		if y, ok = validatorExact(other); !ok {
+			return false
+		}
+	} else {
+		bits := 64
+		if v.Kind() == reflect.Float32 {
+			bits = 32
+		}
+
+		if v.CanFloat() {
+			f, _ := strconv.ParseFloat(param, bits)
+			y = new(big.Float).SetFloat64(f)
+		} else {
+			y, _ = new(big.Float).SetPrec(256).SetString(param)
+		}
+	}
+
+	switch cmp := x.Cmp(y); rule {
+	case "min", "gte":
+		return cmp >= 0
+	case "max":
+		return cmp <= 0
+	case "len":
+		return cmp == 0
+	}
+
+	panic("unknown rule: " + rule)
+}
+
+// validatorExact returns the length of strings (in runes), slices and maps, or the value of numbers.
+// It returns false for NaN, which fails all the comparisons.
+func validatorExact(v reflect.Value) (*big.Float, bool) {
+	switch {
+	case v.Kind() == reflect.String:
+		return new(big.Float).SetInt64(int64(utf8.RuneCountInString(v.String()))), true
+	case v.Kind() == reflect.Slice, v.Kind() == reflect.Map:
+		return new(big.Float).SetInt64(int64(v.Len())), true
+	case v.CanInt():
+		return new(big.Float).SetInt64(v.Int()), true
+	case v.CanUint():
+		return new(big.Float).SetUint64(v.Uint()), true
+	case v.CanFloat():
+		if math.IsNaN(v.Float()) {
+			return nil, false
+		}
+		return new(big.Float).SetFloat64(v.Float()), true
+	}
+
+	panic("unsupported kind: " + v.Kind().String())
+}
+`
+
+// GenerateFuzz generates the formatted source of the test file in the package pkg, with the function
+// Fuzz<Struct>Validate of every struct. The function builds the instance of the struct from the fuzz input
+// and checks that Validate does not panic and returns the violation of the first rule, which the independent
+// interpreter of the rules rejects, or no error, when the interpreter accepts all of them.
+// Structs with the fields of types, which can not be built from the fuzz input, are skipped with the comment.
+func GenerateFuzz(structs []Struct, pkg string) ([]byte, error) {
+	out := &bytes.Buffer{}
+	out.WriteString(header)
+	fmt.Fprintf(out, "package %s\n\n", pkg)
+	out.WriteString("import (\n\t\"errors\"\n\t\"math\"\n\t\"math/big\"\n\t\"reflect\"\n\t\"strconv\"\n\t\"testing\"\n\t\"unicode/utf8\"\n\n\t\"" + ValidationPkg + "\"\n)\n")
+	for _, str := range structs {
+		if err := writeFuzz(out, str); err != nil {
+			fmt.Fprintf(out, "\n// %s: fuzzing skipped, %s\n", str.Name, err)
+		}
+	}
+	out.WriteString(fuzzOracle)
+
+	formatted, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting fuzz tests: %w", err)
+	}
+
+	return formatted, nil
+}
+
+// fuzzField is the field of the struct built from the fuzz input.
+type fuzzField struct {
+	name string
+	typ  Type
+}
+
+func (f fuzzField) arg() string {
+	return "arg" + f.name
+}
+
+func writeFuzz(out *bytes.Buffer, str Struct) error {
+	if len(str.TypeParams) != 0 {
+		return fmt.Errorf("generic struct can not be instantiated without the type arguments")
+	}
+
+	types := fieldTypes(str)
+	var fields []fuzzField
+	add := func(name string) error {
+		t, ok := types[name]
+		if !ok {
+			return fmt.Errorf("field %q not found", name)
+		}
+
+		if !slices.ContainsFunc(fields, func(f fuzzField) bool { return f.name == name }) {
+			fields = append(fields, fuzzField{name: name, typ: t})
+		}
+
+		return nil
+	}
+
+	var checks []string
+	for _, field := range str.Fields {
+		if err := add(field.Name); err != nil {
+			return err
+		}
+
+		for _, alts := range field.Validations {
+			conds := make([]string, 0, len(alts))
+			for _, rule := range alts {
+				gen := GeneratorFor(rule.Name)
+				if gen == nil {
+					return fmt.Errorf("unknown rule %q of field %q", rule.Name, field.Name)
+				}
+
+				if _, err := gen.Generate(rule, str, field); err != nil {
+					return fmt.Errorf("rule %q of field %q: %w", rule.Name, field.Name, err)
+				}
+
+				if !slices.Contains(fuzzRules, rule.Name) {
+					return fmt.Errorf("rule %q of field %q is not known to the independent interpreter", rule.Name, field.Name)
+				}
+
+				other := "reflect.Value{}"
+				if rule.Name == Eqfield || rule.Name == Gte {
+					if err := add(rule.Param); err != nil {
+						return err
+					}
+					other = "reflect.ValueOf(v." + rule.Param + ")"
+				}
+
+				conds = append(conds, fmt.Sprintf("validatorSatisfies(reflect.ValueOf(v.%s), %q, %q, %s)", field.Name, rule.Name, rule.Param, other))
+			}
+
+			checks = append(checks, fmt.Sprintf("{%q, %s},", field.Name+" "+alts.Tag(), strings.Join(conds, " || ")))
+		}
+	}
+
+	valid, err := validFixture(str, DefaultGroup)
+	var params, build []string
+	seeds := [][]string{nil, nil}
+	for _, f := range fields {
+		t := f.typ
+		seeds[0] = append(seeds[0], fuzzSeed(t, reflect.Value{})...)
+		if err == nil {
+			seeds[1] = append(seeds[1], fuzzSeed(t, valid.FieldByName(f.name))...)
+		}
+		switch {
+		case slices.Contains(fuzzScalars, t):
+			params = append(params, f.arg()+" "+string(t))
+			build = append(build, fmt.Sprintf("v.%s = %s", f.name, f.arg()))
+		case t.IsPtr() && slices.Contains(fuzzScalars, t[1:]):
+			params = append(params, f.arg()+"Nil bool", f.arg()+" "+string(t[1:]))
+			build = append(build, fmt.Sprintf("if !%sNil {\nv.%s = &%s\n}", f.arg(), f.name, f.arg()))
+		case t.IsSlice():
+			params = append(params, f.arg()+" uint8")
+			build = append(build, fmt.Sprintf("v.%s = make(%s, %s%%%d)", f.name, t, f.arg(), fuzzMaxLen))
+		case t.IsMap():
+			key := Type(strings.TrimSuffix(strings.TrimPrefix(string(t), "map["), "]"+string(t.Elem())))
+			k := fmt.Sprintf("%s(i)", key)
+			switch {
+			case key.IsString():
+				k = "strconv.Itoa(i)"
+			case !key.IsInteger():
+				return fmt.Errorf("unsupported key of map: %q", t)
+			}
+
+			params = append(params, f.arg()+" uint8")
+			build = append(build, fmt.Sprintf("v.%s = %s{}\nfor i := range int(%s %% %d) {\nv.%s[%s] = *new(%s)\n}", f.name, t, f.arg(), fuzzMaxLen, f.name, k, t.Elem()))
+		default:
+			return fmt.Errorf("unsupported type of field %q: %q", f.name, t)
+		}
+	}
+
+	if err != nil || slices.Equal(seeds[0], seeds[1]) {
+		// without the valid instance, or when it is the zero one, the zero one is the only seed
+		seeds = seeds[:1]
+	}
+
+	fmt.Fprintf(out, "\nfunc Fuzz%sValidate(f *testing.F) {\n", str.Name)
+	for _, seed := range seeds {
+		fmt.Fprintf(out, "f.Add(%s)\n", strings.Join(seed, ", "))
+	}
+	fmt.Fprintf(out, "f.Fuzz(func(t *testing.T, %s) {\n", strings.Join(params, ", "))
+	fmt.Fprintf(out, "v := %s{}\n%s\n\n", str.Name, strings.Join(build, "\n"))
+	out.WriteString("checks := []struct {\nviolation string\nok bool\n}{\n" + strings.Join(checks, "\n") + "\n}\n\n")
+	out.WriteString("expected := \"\"\nfor _, c := range checks {\nif !c.ok {\nexpected = c.violation\nbreak\n}\n}\n\n")
+	fmt.Fprintf(out, "got := \"\"\nerr := v.%s()\n", ValidateMethod(str))
+	out.WriteString("var fieldErr *validation.FieldError\nif errors.As(err, &fieldErr) {\ngot = fieldErr.StructField + \" \" + fieldErr.Tag\n}\n\n")
+	out.WriteString("if got != expected || (err != nil && fieldErr == nil) {\n")
+	out.WriteString("t.Errorf(\"Validate of %#v returned: %v, expected violation: %q\", v, err, expected)\n}\n})\n}\n")
+
+	return nil
+}
+
+// fuzzSeed returns the fuzz arguments of the field of the type with the value of the fixture,
+// or with the zero value, when the value is invalid.
+func fuzzSeed(t Type, v reflect.Value) []string {
+	if !v.IsValid() {
+		if rt, err := reflectType(t); err == nil {
+			v = reflect.Zero(rt)
+		}
+	}
+
+	switch {
+	case t.IsString():
+		return []string{strconv.Quote(v.String())}
+	case t.IsBool():
+		return []string{"false"}
+	case t.IsNumber():
+		lit, _ := fixtureLiteral(t, v)
+		if lit == "" {
+			lit = "0"
+		}
+		return []string{fmt.Sprintf("%s(%s)", t, lit)}
+	case t.IsPtr():
+		elem := reflect.Value{}
+		if !v.IsNil() {
+			elem = v.Elem()
+		}
+		return append([]string{strconv.FormatBool(v.IsNil())}, fuzzSeed(t[1:], elem)...)
+	}
+
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Map {
+		return []string{"uint8(0)"}
+	}
+
+	return []string{fmt.Sprintf("uint8(%d)", v.Len()%fuzzMaxLen)}
+}
+
+// fieldTypes returns the types of all the fields of the struct, keyed by the field name.
+func fieldTypes(str Struct) map[string]Type {
+	out := map[string]Type{}
+	for _, f := range str.Fields {
+		out[f.Name] = f.Type
+	}
+
+	if str.Ast == nil {
+		return out
+	}
+
+	for _, f := range str.Ast.Fields.List {
+		for _, ident := range f.Names {
+			out[ident.Name] = Type(types.ExprString(f.Type))
+		}
+	}
+
+	return out
+}
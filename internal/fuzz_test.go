@@ -0,0 +1,58 @@
+package internal_test
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/paluszkiewiczB/validator/internal"
+)
+
+func Test_GenerateFuzz(t *testing.T) {
+	internal.Log = newTestLog(t)
+
+	src := "package example\n" +
+		"type User struct {\n" +
+		"	Name  string         `validate:\"required,max=3\"`\n" +
+		"	Age   *int8          `validate:\"required\"`\n" +
+		"	Tags  map[string]int `validate:\"len=2\"`\n" +
+		"	Score float64        `validate:\"gte=Limit\"`\n" +
+		"	Limit int64\n" +
+		"}\n" +
+		"type Nested struct {\n" +
+		"	Sizes *[]int `validate:\"required\"`\n" +
+		"}\n"
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "example.go", src, 0)
+	if err != nil {
+		t.Fatalf("parsing source: %v", err)
+	}
+
+	structs, err := internal.FindStructs(fset, f)
+	if err != nil {
+		t.Fatalf("finding structs: %v", err)
+	}
+
+	out, err := internal.GenerateFuzz(structs, "example")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, expected := range []string{
+		"// Nested: fuzzing skipped, unsupported type of field \"Sizes\": \"*[]int\"\n",
+		"\tf.Add(\"\", true, int8(0), uint8(0), float64(0), int64(0))\n",
+		"\tf.Add(\"a\", false, int8(0), uint8(2), float64(0), int64(0))\n",
+		"f.Fuzz(func(t *testing.T, argName string, argAgeNil bool, argAge int8, argTags uint8, argScore float64, argLimit int64) {\n",
+		"\t\tif !argAgeNil {\n\t\t\tv.Age = &argAge\n\t\t}\n",
+		"\t\tfor i := range int(argTags % 16) {\n\t\t\tv.Tags[strconv.Itoa(i)] = *new(int)\n\t\t}\n",
+		`{"Name max", validatorSatisfies(reflect.ValueOf(v.Name), "max", "3", reflect.Value{})},`,
+		`{"Score gte", validatorSatisfies(reflect.ValueOf(v.Score), "gte", "Limit", reflect.ValueOf(v.Limit))},`,
+		"func validatorSatisfies(v reflect.Value, rule, param string, other reflect.Value) bool {\n",
+	} {
+		if !strings.Contains(string(out), expected) {
+			t.Errorf("expected output to contain:\n%s\ngot:\n%s", expected, out)
+		}
+	}
+}
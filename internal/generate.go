@@ -0,0 +1,303 @@
+package internal
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/token"
+	"slices"
+	"strconv"
+
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// header is the comment marking the file as generated, see https://go.dev/s/generatedcode.
+const header = "// Code generated by validator. DO NOT EDIT.\n\n"
+
+// GenerateFile generates the formatted source of the file in the package pkg, with the methods validating the structs
+// and the methods normalizing the structs with the modifiers.
+// The output depends only on the structs, the rules are validated in the order of the tag and the first
+// violated rule is returned, so generating the same input twice gives the same bytes. With CollectAll Errors
+// of the struct the first violated rule of every field is returned.
+func GenerateFile(structs []Struct, pkg string) ([]byte, error) {
+	methods := make([]ast.Decl, 0)
+	var imports []string
+	for _, str := range structs {
+		stmts, imps, err := validationStmts(str, DefaultGroup)
+		if err != nil {
+			return nil, err
+		}
+
+		imports = append(imports, imps...)
+
+		// TODO: check if there are multiple structs and join them into single error (like errors.Join).
+		// Make sure the solution is compatible with go-playground/validator type validator.ValidationErrors,
+		// so it can be used with the translator?
+
+		stmts = returnErrors(str, stmts)
+
+		doc := "// Validate implements Validator."
+		if str.Unexported {
+			doc = "// validate validates the struct, the package can wrap it in its own Validate."
+		}
+
+		methods = append(methods, &ast.FuncDecl{
+			Doc: &ast.CommentGroup{
+				List: []*ast.Comment{
+					{Text: doc},
+				},
+			},
+			Recv: &ast.FieldList{List: []*ast.Field{{Type: &ast.Ident{Name: Receiver(str)}}}},
+			Name: &ast.Ident{Name: ValidateMethod(str)},
+			Type: &ast.FuncType{Results: &ast.FieldList{List: []*ast.Field{{Type: &ast.Ident{Name: "error"}}}}},
+			Body: &ast.BlockStmt{List: stmts},
+		})
+
+		if groups := str.Groups(); len(groups) != 0 {
+			method, imps, err := validateGroupMethod(str, groups)
+			if err != nil {
+				return nil, err
+			}
+
+			methods = append(methods, method)
+			imports = append(imports, imps...)
+		}
+
+		if str.HasModifiers() {
+			normalize, imps, err := normalizeMethods(str)
+			if err != nil {
+				return nil, err
+			}
+
+			methods = append(methods, normalize...)
+			imports = append(imports, imps...)
+		}
+	}
+
+	file := &ast.File{
+		Name:  &ast.Ident{Name: pkg},
+		Decls: methods,
+	}
+
+	fset := token.NewFileSet()
+	slices.Sort(imports)
+	imports = slices.Compact(imports)
+	for _, imp := range imports {
+		astutil.AddImport(fset, file, imp)
+	}
+
+	buf := bytes.NewBufferString(header)
+	if err := format.Node(buf, fset, file); err != nil {
+		return nil, fmt.Errorf("printing file: %w", err)
+	}
+
+	// the nodes have no positions, so the printed source is formatted again to get the output of gofmt
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting file: %w", err)
+	}
+
+	return formatted, nil
+}
+
+// validationStmts generates the statements validating all the fields of the struct in the group.
+// With CollectAll the statements of the field are chained with else, see collectErrors.
+func validationStmts(str Struct, group string) ([]ast.Stmt, []string, error) {
+	var stmts []ast.Stmt
+	var imports []string
+	for _, field := range str.Fields {
+		field = field.InGroup(group)
+		vals, elems, dive := field.Validations.Dive()
+		field.Validations = vals
+		fieldStmts, chain, imps, err := ruleStmts(str, field)
+		if err != nil {
+			return nil, nil, fmt.Errorf("group: %q, %w", group, err)
+		}
+
+		stmts = append(stmts, fieldStmts...)
+		imports = append(imports, imps...)
+
+		// the elements and the nested errors are validated only, when the field satisfies its rules
+		var after []ast.Stmt
+		if dive {
+			loop, imps, err := diveStmt(str, field.Elem(elems))
+			if err != nil {
+				return nil, nil, fmt.Errorf("group: %q, %w", group, err)
+			}
+
+			after = append(after, loop)
+			imports = append(imports, imps...)
+		}
+
+		if field.Nested {
+			after = append(after, nestedValidation(str, field))
+			if str.Errors == CollectAll {
+				imports = append(imports, ValidationPkg)
+			}
+		}
+
+		switch {
+		case len(after) == 0:
+		case str.Errors == CollectAll && chain != nil:
+			chain.Else = &ast.BlockStmt{List: after}
+		default:
+			stmts = append(stmts, after...)
+		}
+	}
+
+	return stmts, imports, nil
+}
+
+// ruleStmts generates the statements validating the field with its validations. With CollectAll they are
+// chained with else, the last statement of the chain is returned, so the chain can be continued.
+func ruleStmts(str Struct, field Field) ([]ast.Stmt, *ast.IfStmt, []string, error) {
+	var stmts []ast.Stmt
+	var imports []string
+	var chain *ast.IfStmt
+	for _, alts := range field.Validations {
+		stmt, imps, err := GenerateValidation(alts, str, field)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		imports = append(imports, imps...)
+		if str.Errors != CollectAll {
+			stmts = append(stmts, stmt)
+			continue
+		}
+
+		collected := collectErrors(stmt.(*ast.IfStmt))
+		if chain == nil {
+			stmts = append(stmts, collected)
+		} else {
+			chain.Else = collected
+		}
+
+		chain = collected
+	}
+
+	return stmts, chain, imports, nil
+}
+
+// diveStmt generates the loop validating every element of the slice with the validations after Dive,
+// see Field.Elem. The elements can not dive again.
+func diveStmt(str Struct, elem Field) (ast.Stmt, []string, error) {
+	if _, _, dive := elem.Validations.Dive(); dive {
+		return nil, nil, fmt.Errorf("field: %q, nested %q is not supported", elem.Name, Dive)
+	}
+
+	if len(elem.Validations) == 0 {
+		return nil, nil, fmt.Errorf("field: %q, %q expects the validations of the elements after it", elem.Name, Dive)
+	}
+
+	stmts, _, imports, err := ruleStmts(str, elem)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &ast.RangeStmt{
+		Key:   &ast.Ident{Name: elem.Index},
+		Value: &ast.Ident{Name: elem.Access},
+		Tok:   token.DEFINE,
+		X:     &ast.Ident{Name: FieldAccess(str, Field{Name: elem.Name})},
+		Body:  &ast.BlockStmt{List: stmts},
+	}, imports, nil
+}
+
+// checkRules generates the validations of the field and of its elements without using them, so the exporters
+// accept exactly the rules, which GenerateFile accepts.
+func checkRules(str Struct, field Field) error {
+	vals, elems, dive := field.Validations.Dive()
+	field.Validations = vals
+	if _, _, _, err := ruleStmts(str, field); err != nil {
+		return err
+	}
+
+	if dive {
+		_, _, err := diveStmt(str, field.Elem(elems))
+		return err
+	}
+
+	return nil
+}
+
+// errsVar is the variable of validation.FieldErrors collected with CollectAll.
+const errsVar = "errs"
+
+// collectErrors replaces the return of the error of the statement with appending it to the errsVar.
+func collectErrors(stmt *ast.IfStmt) *ast.IfStmt {
+	for i, s := range stmt.Body.List {
+		if ret, ok := s.(*ast.ReturnStmt); ok {
+			stmt.Body.List[i] = &ast.AssignStmt{
+				Lhs: []ast.Expr{&ast.Ident{Name: errsVar}},
+				Tok: token.ASSIGN,
+				Rhs: []ast.Expr{&ast.CallExpr{Fun: &ast.Ident{Name: "append"}, Args: []ast.Expr{&ast.Ident{Name: errsVar}, ret.Results[0]}}},
+			}
+		}
+	}
+
+	return stmt
+}
+
+// returnErrors returns the statements followed by the return of no error.
+// With CollectAll they are surrounded with the declaration of the errsVar and the return of the collected errors.
+func returnErrors(str Struct, stmts []ast.Stmt) []ast.Stmt {
+	if str.Errors != CollectAll || len(stmts) == 0 {
+		return append(stmts, NoError())
+	}
+
+	decl := &ast.DeclStmt{Decl: &ast.GenDecl{Tok: token.VAR, Specs: []ast.Spec{&ast.ValueSpec{
+		Names: []*ast.Ident{{Name: errsVar}},
+		Type:  &ast.Ident{Name: "validation.FieldErrors"},
+	}}}}
+
+	collected := &ast.IfStmt{
+		Cond: &ast.Ident{Name: "len(" + errsVar + ") != 0"},
+		Body: &ast.BlockStmt{List: []ast.Stmt{&ast.ReturnStmt{Results: []ast.Expr{&ast.Ident{Name: errsVar}}}}},
+	}
+
+	return append(append([]ast.Stmt{decl}, stmts...), collected, NoError())
+}
+
+// validateGroupMethod generates the method ValidateGroup(group string) error, which validates the struct
+// with the validations of the group. The DefaultGroup is validated by the method Validate.
+// The methods are unexported for the Unexported struct.
+func validateGroupMethod(str Struct, groups []string) (*ast.FuncDecl, []string, error) {
+	imports := []string{"fmt"}
+	clauses := []ast.Stmt{&ast.CaseClause{
+		List: []ast.Expr{&ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(DefaultGroup)}},
+		Body: []ast.Stmt{&ast.ReturnStmt{Results: []ast.Expr{&ast.Ident{Name: ReceiverName(str) + "." + ValidateMethod(str) + "()"}}}},
+	}}
+
+	for _, group := range groups {
+		stmts, imps, err := validationStmts(str, group)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		imports = append(imports, imps...)
+		clauses = append(clauses, &ast.CaseClause{
+			List: []ast.Expr{&ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(group)}},
+			Body: returnErrors(str, stmts),
+		})
+	}
+
+	return &ast.FuncDecl{
+		Doc: &ast.CommentGroup{
+			List: []*ast.Comment{
+				{Text: "// " + ValidateGroupMethod(str) + " validates the fields with the validations of the group."},
+			},
+		},
+		Recv: &ast.FieldList{List: []*ast.Field{{Type: &ast.Ident{Name: Receiver(str)}}}},
+		Name: &ast.Ident{Name: ValidateGroupMethod(str)},
+		Type: &ast.FuncType{
+			Params:  &ast.FieldList{List: []*ast.Field{{Names: []*ast.Ident{{Name: "group"}}, Type: &ast.Ident{Name: "string"}}}},
+			Results: &ast.FieldList{List: []*ast.Field{{Type: &ast.Ident{Name: "error"}}}},
+		},
+		Body: &ast.BlockStmt{List: []ast.Stmt{
+			&ast.SwitchStmt{Tag: &ast.Ident{Name: "group"}, Body: &ast.BlockStmt{List: clauses}},
+			&ast.ReturnStmt{Results: []ast.Expr{&ast.Ident{Name: `fmt.Errorf("unknown validation group: %q", group)`}}},
+		}},
+	}, imports, nil
+}
@@ -0,0 +1,142 @@
+package internal_test
+
+import (
+	"bytes"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/paluszkiewiczB/validator/internal"
+)
+
+const source = `package example
+
+type User struct {
+	Name     string  ` + "`" + `validate:"eqfield=Nick,required,gte=Age|eqfield=Nick,eqfield=Other" validate.create:"required,eqfield=Other"` + "`" + `
+	Nick     string
+	Other    string
+	Age      int
+	Email    *string ` + "`" + `validate:"update:required;required"` + "`" + `
+}
+
+type Account struct {
+	ID string ` + "`" + `validate:"required"` + "`" + `
+}
+`
+
+func generate(t *testing.T, src string) string {
+	t.Helper()
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "example.go", src, parser.ParseComments|parser.AllErrors)
+	if err != nil {
+		t.Fatalf("parsing source: %v", err)
+	}
+
+	structs, err := internal.FindStructs(fset, f)
+	if err != nil {
+		t.Fatalf("finding structs: %v", err)
+	}
+
+	out, err := internal.GenerateFile(structs, "example")
+	if err != nil {
+		t.Fatalf("generating file: %v", err)
+	}
+
+	return string(out)
+}
+
+func Test_GenerateFile_Reproducible(t *testing.T) {
+	internal.Log = newTestLog(t)
+
+	first := generate(t, source)
+	for range 20 {
+		if next := generate(t, source); next != first {
+			t.Fatalf("expected the same output, got:\n%s\nand:\n%s", first, next)
+		}
+	}
+}
+
+func Test_GenerateFile_TagOrder(t *testing.T) {
+	internal.Log = newTestLog(t)
+
+	out := generate(t, source)
+	validate := out[strings.Index(out, "func (u User) Validate() error"):strings.Index(out, "func (u User) ValidateGroup")]
+	expected := []string{
+		`if u.Name != u.Nick {`,
+		`if len(u.Name) == 0 {`,
+		`if !(float64(u.Name) >= float64(u.Age) || u.Name == u.Nick) {`,
+		`if u.Name != u.Other {`,
+		`if u.Email == nil {`,
+	}
+
+	last := -1
+	for _, stmt := range expected {
+		i := strings.Index(validate, stmt)
+		if i <= last {
+			t.Fatalf("expected %q after position %d, got %d in:\n%s", stmt, last, i, validate)
+		}
+		last = i
+	}
+
+	if !bytes.HasPrefix([]byte(out), []byte("// Code generated by validator. DO NOT EDIT.\n\npackage example\n")) {
+		t.Errorf("expected generated code header, got:\n%s", out)
+	}
+}
+
+func Test_GenerateFile_CollectAll(t *testing.T) {
+	internal.Log = newTestLog(t)
+	internal.Errors = internal.CollectAll
+	t.Cleanup(func() { internal.Errors = internal.FailFast })
+
+	out := generate(t, source)
+	validate := out[strings.Index(out, "func (u User) Validate() error"):strings.Index(out, "func (u User) ValidateGroup")]
+	expected := []string{
+		`var errs validation.FieldErrors`,
+		`if u.Name != u.Nick {`,
+		`errs = append(errs, &validation.FieldError{`,
+		`} else if len(u.Name) == 0 {`,
+		`} else if !(float64(u.Name) >= float64(u.Age) || u.Name == u.Nick) {`,
+		`} else if u.Name != u.Other {`,
+		"}\n\tif u.Email == nil {",
+		`if len(errs) != 0 {`,
+		`return errs`,
+	}
+
+	last := -1
+	for _, stmt := range expected {
+		i := strings.Index(validate, stmt)
+		if i <= last {
+			t.Fatalf("expected %q after position %d, got %d in:\n%s", stmt, last, i, validate)
+		}
+		last = i
+	}
+}
+
+func Test_ParseGroupsAt_Positions(t *testing.T) {
+	internal.Log = newTestLog(t)
+
+	groups, err := internal.ParseGroupsAt(raw(`validate:"required,eqfield=A|gte=B;create:eqfield=C"`), token.Position{Filename: "file.go", Offset: 10, Line: 2, Column: 5})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cases := map[string]struct {
+		rule   internal.Rule
+		column int
+	}{
+		"required":   {rule: groups[""][0][0], column: 16},
+		"eqfield":    {rule: groups[""][1][0], column: 25},
+		"gte":        {rule: groups[""][1][1], column: 35},
+		"group rule": {rule: groups["create"][0][0], column: 48},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			pos := token.Position{Filename: "file.go", Offset: 10 + c.column - 5, Line: 2, Column: c.column}
+			if c.rule.Pos != pos {
+				t.Errorf("expected position %v, got %v of rule %v", pos, c.rule.Pos, c.rule)
+			}
+		})
+	}
+}
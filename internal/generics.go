@@ -0,0 +1,274 @@
+package internal
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+)
+
+// TypeParam is the type parameter of the generic struct, e.g. 'T cmp.Ordered' of `type Range[T cmp.Ordered] struct{}`.
+type TypeParam struct {
+	Name string
+	// Constraint is the source of the constraint, e.g. 'cmp.Ordered'.
+	Constraint string
+	// Validator is true, when the constraint has the method 'Validate() error', the fields of the type parameter
+	// are validated with it, see Field.Nested.
+	Validator bool
+	// kind is the operations permitted by the constraint
+	kind constraintKind
+}
+
+// constraintKind are the operations permitted by the constraint of the type parameter,
+// every kind permits the operations of the previous ones.
+type constraintKind int
+
+const (
+	anyKind        constraintKind = iota
+	comparableKind                // == and !=
+	orderedKind                   // <, <=, >= and >
+	numberKind                    // comparison with the untyped number
+	integerKind                   // comparison with the untyped integer
+)
+
+// Comparable returns true, when the values of the type parameter can be compared with == and !=.
+func (p TypeParam) Comparable() bool {
+	return p.kind >= comparableKind
+}
+
+// Ordered returns true, when the values of the type parameter can be compared with < and >.
+func (p TypeParam) Ordered() bool {
+	return p.kind >= orderedKind
+}
+
+// Number returns true, when all the types of the type parameter are numbers.
+func (p TypeParam) Number() bool {
+	return p.kind >= numberKind
+}
+
+// Integer returns true, when all the types of the type parameter are integers.
+func (p TypeParam) Integer() bool {
+	return p.kind == integerKind
+}
+
+// TypeParam returns the type parameter of the struct, which is the type t.
+func (s Struct) TypeParam(t Type) (TypeParam, bool) {
+	for _, p := range s.TypeParams {
+		if Type(p.Name) == t {
+			return p, true
+		}
+	}
+
+	return TypeParam{}, false
+}
+
+// TypeArgs returns the type parameters of the struct as the type arguments, e.g. '[K, V]', or empty string.
+func (s Struct) TypeArgs() string {
+	if len(s.TypeParams) == 0 {
+		return ""
+	}
+
+	return "[" + strings.Join(mapSlice(s.TypeParams, func(p TypeParam) string { return p.Name }), ", ") + "]"
+}
+
+// TypeParams returns the type parameters of the type declared in the file, e.g. for the Struct built by the analyzer.
+func TypeParams(t *ast.TypeSpec, f *ast.File) []TypeParam {
+	return typeParams(t, typeDecls(f))
+}
+
+// typeDecls returns the type declarations of the file by the name of the type.
+func typeDecls(f *ast.File) map[string]*ast.TypeSpec {
+	decls := map[string]*ast.TypeSpec{}
+	for _, decl := range f.Decls {
+		if d, ok := decl.(*ast.GenDecl); ok && d.Tok == token.TYPE {
+			for _, spec := range d.Specs {
+				decls[spec.(*ast.TypeSpec).Name.Name] = spec.(*ast.TypeSpec)
+			}
+		}
+	}
+
+	return decls
+}
+
+// typeParams returns the type parameters of the type, decls are the type declarations of the file,
+// which the constraints can refer to.
+func typeParams(t *ast.TypeSpec, decls map[string]*ast.TypeSpec) []TypeParam {
+	if t.TypeParams == nil {
+		return nil
+	}
+
+	var params []TypeParam
+	for _, field := range t.TypeParams.List {
+		for _, name := range field.Names {
+			params = append(params, TypeParam{
+				Name:       name.Name,
+				Constraint: types.ExprString(field.Type),
+				Validator:  hasValidate(field.Type, decls),
+				kind:       kindOf(field.Type, decls),
+			})
+		}
+	}
+
+	return params
+}
+
+// Constraints of the packages cmp and golang.org/x/exp/constraints.
+var constraintKinds = map[string]constraintKind{
+	"cmp.Ordered":          orderedKind,
+	"constraints.Ordered":  orderedKind,
+	"constraints.Integer":  integerKind,
+	"constraints.Signed":   integerKind,
+	"constraints.Unsigned": integerKind,
+	"constraints.Float":    numberKind,
+	"constraints.Complex":  comparableKind,
+}
+
+// kindOf returns the kind of the constraint or the term of the union.
+func kindOf(expr ast.Expr, decls map[string]*ast.TypeSpec) constraintKind {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		switch t := Type(e.Name); {
+		case t == "comparable", t == "bool", t == "complex64", t == "complex128":
+			return comparableKind
+		case t.IsInteger():
+			return integerKind
+		case t.IsNumber():
+			return numberKind
+		case t.IsString():
+			return orderedKind
+		}
+
+		if d, ok := decls[e.Name]; ok && d.TypeParams == nil {
+			return kindOf(d.Type, decls)
+		}
+	case *ast.SelectorExpr:
+		return constraintKinds[types.ExprString(e)]
+	case *ast.UnaryExpr:
+		if e.Op == token.TILDE {
+			return kindOf(e.X, decls)
+		}
+	case *ast.BinaryExpr:
+		if e.Op == token.OR {
+			return min(kindOf(e.X, decls), kindOf(e.Y, decls))
+		}
+	case *ast.ParenExpr:
+		return kindOf(e.X, decls)
+	case *ast.InterfaceType:
+		// the type set of the interface is the intersection of its embedded elements
+		kind := anyKind
+		for _, m := range e.Methods.List {
+			if len(m.Names) == 0 {
+				kind = max(kind, kindOf(m.Type, decls))
+			}
+		}
+
+		return kind
+	}
+
+	return anyKind
+}
+
+// hasValidate returns true, when the constraint has the method 'Validate() error', declared in the interface
+// or its embedded interfaces.
+func hasValidate(expr ast.Expr, decls map[string]*ast.TypeSpec) bool {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		if d, ok := decls[e.Name]; ok && d.TypeParams == nil {
+			return hasValidate(d.Type, decls)
+		}
+	case *ast.ParenExpr:
+		return hasValidate(e.X, decls)
+	case *ast.InterfaceType:
+		for _, m := range e.Methods.List {
+			if len(m.Names) == 0 {
+				if hasValidate(m.Type, decls) {
+					return true
+				}
+
+				continue
+			}
+
+			fn, ok := m.Type.(*ast.FuncType)
+			if ok && m.Names[0].Name == "Validate" && fn.Params.NumFields() == 0 && fn.Results.NumFields() == 1 && types.ExprString(fn.Results.List[0].Type) == "error" {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// isNested returns true, when the field of the type is validated with the method Validate of the type parameter:
+// the type parameter itself, the pointer to it or the slice of it.
+func isNested(t Type, params []TypeParam) bool {
+	for _, p := range params {
+		if p.Validator && (t == Type(p.Name) || t == Type("*"+p.Name) || t == Type("[]"+p.Name)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// nestedValidation generates the statement returning the error of the method Validate of the field,
+// or of every element of the slice, see Field.Nested. With CollectAll the errors are collected.
+func nestedValidation(str Struct, field Field) ast.Stmt {
+	access := FieldAccess(str, field)
+	value := access
+	switch {
+	case field.Type.IsSlice():
+		value = "elem"
+	case field.Type.IsPtr():
+		value = "(*" + access + ")"
+	}
+
+	var handle []ast.Stmt
+	if str.Errors == CollectAll {
+		handle = []ast.Stmt{
+			&ast.AssignStmt{
+				Lhs: []ast.Expr{&ast.Ident{Name: "nested"}, &ast.Ident{Name: "ok"}},
+				Tok: token.DEFINE,
+				Rhs: []ast.Expr{&ast.Ident{Name: "validation.AsFieldErrors(err)"}},
+			},
+			&ast.IfStmt{
+				Cond: &ast.Ident{Name: "!ok"},
+				Body: &ast.BlockStmt{List: []ast.Stmt{&ast.ReturnStmt{Results: []ast.Expr{&ast.Ident{Name: "err"}}}}},
+			},
+			&ast.AssignStmt{
+				Lhs: []ast.Expr{&ast.Ident{Name: errsVar}},
+				Tok: token.ASSIGN,
+				Rhs: []ast.Expr{&ast.Ident{Name: "append(" + errsVar + ", nested...)"}},
+			},
+		}
+	} else {
+		handle = []ast.Stmt{&ast.ReturnStmt{Results: []ast.Expr{&ast.Ident{Name: "err"}}}}
+	}
+
+	var stmt ast.Stmt = &ast.IfStmt{
+		Init: &ast.AssignStmt{
+			Lhs: []ast.Expr{&ast.Ident{Name: "err"}},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{&ast.CallExpr{Fun: &ast.Ident{Name: value + ".Validate"}}},
+		},
+		Cond: &ast.Ident{Name: "err != nil"},
+		Body: &ast.BlockStmt{List: handle},
+	}
+
+	switch {
+	case field.Type.IsSlice():
+		stmt = &ast.RangeStmt{
+			Key:   &ast.Ident{Name: "_"},
+			Value: &ast.Ident{Name: value},
+			Tok:   token.DEFINE,
+			X:     &ast.Ident{Name: access},
+			Body:  &ast.BlockStmt{List: []ast.Stmt{stmt}},
+		}
+	case field.Type.IsPtr():
+		stmt = &ast.IfStmt{
+			Cond: &ast.Ident{Name: access + " != nil"},
+			Body: &ast.BlockStmt{List: []ast.Stmt{stmt}},
+		}
+	}
+
+	return stmt
+}
@@ -0,0 +1,97 @@
+package internal_test
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/paluszkiewiczB/validator/internal"
+)
+
+const genericSource = `package example
+
+type Validator interface {
+	Validate() error
+}
+
+type Number interface {
+	~int | ~int64 | ~float64
+}
+
+type Page[K comparable, V interface{ Validator }] struct {
+	Key   K   ` + "`" + `validate:"required"` + "`" + `
+	Items []V ` + "`" + `validate:"max=100"` + "`" + `
+	First *V
+	Last  V
+}
+
+type Range[T Number] struct {
+	Min T ` + "`" + `validate:"min=0.5"` + "`" + `
+	Max T ` + "`" + `validate:"gte=Min"` + "`" + `
+}
+`
+
+func Test_GenerateFile_Generics(t *testing.T) {
+	internal.Log = newTestLog(t)
+
+	out := generate(t, genericSource)
+	for _, expected := range []string{
+		"func (p Page[K, V]) Validate() error {",
+		"if p.Key == *new(K) {",
+		"if len(p.Items) > 100 {",
+		"for _, elem := range p.Items {\n\t\tif err := elem.Validate(); err != nil {\n\t\t\treturn err\n\t\t}\n\t}",
+		"if p.First != nil {\n\t\tif err := (*p.First).Validate(); err != nil {",
+		"if err := p.Last.Validate(); err != nil {",
+		"func (r Range[T]) Validate() error {",
+		"if !(r.Min >= 0.5) {",
+		"if !(r.Max >= r.Min) {",
+	} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("expected %q in:\n%s", expected, out)
+		}
+	}
+}
+
+func Test_GenerateFile_Generics_CollectAll(t *testing.T) {
+	internal.Log = newTestLog(t)
+	internal.Errors = internal.CollectAll
+	t.Cleanup(func() { internal.Errors = internal.FailFast })
+
+	out := generate(t, genericSource)
+	expected := "} else {\n\t\tfor _, elem := range p.Items {\n\t\t\tif err := elem.Validate(); err != nil {\n\t\t\t\tnested, ok := validation.AsFieldErrors(err)"
+	if !strings.Contains(out, expected) {
+		t.Errorf("expected %q in:\n%s", expected, out)
+	}
+}
+
+func Test_GenerateFile_Generics_Errors(t *testing.T) {
+	internal.Log = newTestLog(t)
+
+	for src, msg := range map[string]string{
+		"type Box[T any] struct {\n\tV T `validate:\"required\"`\n}":                      `type parameter T any is not comparable`,
+		"type Box[T comparable] struct {\n\tV T `validate:\"gte=W\"`\n\tW T\n}":           `type parameter T comparable is not ordered`,
+		"type Box[T ~string] struct {\n\tV T `validate:\"max=3\"`\n}":                     `type parameter T ~string is not a number`,
+		"type Box[T ~int | ~uint] struct {\n\tV T `validate:\"max=0.5\"`\n}":              `validation "max" expects integer, got: "0.5"`,
+		"type Box[T ~int, U ~int] struct {\n\tV T `validate:\"gte=W\"`\n\tW U\n}":         `validation "gte" expects field "W" of type T, got: U`,
+		"type Box[T interface{ any }] struct {\n\tV T `validate:\"eqfield=W\"`\n\tW T\n}": `type parameter T interface{any} is not comparable`,
+	} {
+		t.Run(src, func(t *testing.T) {
+			fset := token.NewFileSet()
+			f, err := parser.ParseFile(fset, "example.go", "package example\n"+src, parser.AllErrors)
+			if err != nil {
+				t.Fatalf("parsing source: %v", err)
+			}
+
+			structs, err := internal.FindStructs(fset, f)
+			if err != nil {
+				t.Fatalf("finding structs: %v", err)
+			}
+
+			_, err = internal.GenerateFile(structs, "example")
+			if err == nil || !strings.Contains(err.Error(), msg) {
+				t.Errorf("expected error %q, got: %v", msg, err)
+			}
+		})
+	}
+}
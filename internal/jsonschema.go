@@ -0,0 +1,354 @@
+package internal
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// SchemaDialect is the JSON Schema dialect of the generated schemas.
+const SchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is the subset of JSON Schema (draft 2020-12) which can express the validations.
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	ID                   string             `json:"$id,omitempty"`
+	Ref                  string             `json:"$ref,omitempty"`
+	Comment              string             `json:"$comment,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Type                 any                `json:"type,omitempty"`
+	Enum                 []any              `json:"enum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	MinProperties        *int               `json:"minProperties,omitempty"`
+	MaxProperties        *int               `json:"maxProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+}
+
+// SchemaRef returns the reference to the schema of the struct, see JSONSchemas.
+func SchemaRef(name string) string {
+	return name + ".schema.json"
+}
+
+// JSONSchemas returns the JSON Schema of every struct, keyed by the struct name.
+// Properties are named after the json tag, fields ignored with `json:"-"` and unexported fields are skipped.
+// Fields of the types of other structs refer to their schemas with SchemaRef.
+// The rules are checked with the generators first, so the rules rejected by GenerateFile fail the export.
+// Rules without the equivalent in JSON Schema, like eqfield, are listed in the $comment of the property.
+func JSONSchemas(structs []Struct) (map[string]*Schema, error) {
+	known := make(map[string]bool, len(structs))
+	for _, s := range structs {
+		known[s.Name] = true
+	}
+
+	b := schemaBuilder{ref: SchemaRef, known: known}
+	out := make(map[string]*Schema, len(structs))
+	for _, str := range structs {
+		s, err := b.object(str)
+		if err != nil {
+			return nil, fmt.Errorf("struct: %q, %w", str.Name, err)
+		}
+
+		s.Schema = SchemaDialect
+		s.ID = SchemaRef(str.Name)
+		s.Title = str.Name
+		out[str.Name] = s
+	}
+
+	return out, nil
+}
+
+type schemaBuilder struct {
+	// ref returns the reference to the schema of the struct
+	ref   func(name string) string
+	known map[string]bool
+	// nullable allows null for the pointer fields, which are not required
+	nullable bool
+	// describe sets descriptions from the doc comments
+	describe bool
+}
+
+// object returns the schema of the struct, with the properties of all the fields of the struct.
+func (b schemaBuilder) object(str Struct) (*Schema, error) {
+	s := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	if b.describe {
+		s.Description = docText(str.Doc)
+	}
+
+	validated := make(map[string]Field, len(str.Fields))
+	for _, f := range str.Fields {
+		validated[f.Name] = f
+	}
+
+	for _, astField := range str.Ast.Fields.List {
+		for _, ident := range astField.Names {
+			if !ident.IsExported() {
+				continue
+			}
+
+			name, ok := jsonName(ident.Name, astField)
+			if !ok {
+				continue
+			}
+
+			field, ok := validated[ident.Name]
+			if !ok {
+				field = Field{Name: ident.Name, Type: Type(types.ExprString(astField.Type)), Ast: astField}
+			}
+
+			if err := checkRules(str, field); err != nil {
+				return nil, fmt.Errorf("field: %q, %w", field.Name, err)
+			}
+
+			prop, required := b.property(field)
+			s.Properties[name] = prop
+			if required {
+				s.Required = append(s.Required, name)
+			}
+		}
+	}
+
+	return s, nil
+}
+
+// property returns the schema of the field and whether it is required.
+func (b schemaBuilder) property(field Field) (*Schema, bool) {
+	s := b.typeSchema(field.Type)
+	required := isRequired(field.Validations)
+	b.apply(s, field.Type, field.Validations)
+
+	if b.describe && field.Ast != nil {
+		s.Description = docText(field.Ast.Doc)
+		if s.Description == "" {
+			s.Description = docText(field.Ast.Comment)
+		}
+	}
+
+	if b.nullable && field.Type.IsPtr() && !required {
+		s = nullable(s)
+	}
+
+	return s, required
+}
+
+// nullable returns the schema allowing null as well.
+func nullable(s *Schema) *Schema {
+	if t, ok := s.Type.(string); ok {
+		s.Type = []string{t, "null"}
+		return s
+	}
+
+	return &Schema{AnyOf: []*Schema{s, {Type: "null"}}}
+}
+
+func docText(doc *ast.CommentGroup) string {
+	if doc == nil {
+		return ""
+	}
+
+	return strings.TrimSpace(doc.Text())
+}
+
+// apply applies the rules to the schema of the type, the rules are checked with the generators by object.
+// Rules after the rule Dive are applied to the items of the array.
+func (b schemaBuilder) apply(s *Schema, t Type, vals Validations) {
+	vals, elems, ok := vals.Dive()
+	if ok && t.IsSlice() && s.Items != nil {
+		b.apply(s.Items, t.Elem(), elems)
+	}
+
+	var skipped []string
+	for _, alts := range vals {
+		if len(alts) == 1 {
+			if !applyRule(s, t, alts[0]) {
+				skipped = append(skipped, alts.String())
+			}
+			continue
+		}
+
+		anyOf := make([]*Schema, 0, len(alts))
+		for _, rule := range alts {
+			alt := &Schema{}
+			if !applyRule(alt, t, rule) {
+				// any value satisfies the rule according to the schema, so does the whole alternative
+				anyOf = nil
+				skipped = append(skipped, alts.String())
+				break
+			}
+			anyOf = append(anyOf, alt)
+		}
+
+		switch {
+		case len(anyOf) == 0:
+		case len(s.AnyOf) == 0:
+			s.AnyOf = anyOf
+		default:
+			s.AllOf = append(s.AllOf, &Schema{AnyOf: anyOf})
+		}
+	}
+
+	if len(skipped) != 0 {
+		s.Comment = "rules without JSON Schema equivalent: " + strings.Join(skipped, ",")
+	}
+}
+
+// applyRule applies the rule to the schema, it returns false if the rule has no equivalent in JSON Schema.
+func applyRule(s *Schema, t Type, rule Rule) bool {
+	base := t.Deref()
+	switch rule.Name {
+	case Required:
+		// the generated code compares the pointers with nil, which is the required list of the object
+		switch {
+		case t.IsString():
+			atLeast(&s.MinLength, 1)
+		case t.IsSlice():
+			atLeast(&s.MinItems, 1)
+		case t.IsMap():
+			atLeast(&s.MinProperties, 1)
+		}
+		return true
+	case Oneof:
+		return setEnum(s, t, oneofValues(rule.Param))
+	case Regexp:
+		if !t.IsString() {
+			return false
+		}
+		s.Pattern = rule.Param
+		return true
+	case Email:
+		if !t.IsString() {
+			return false
+		}
+		s.Format = "email"
+		return true
+	case Dive:
+		return t.IsSlice()
+	case Min, Max, Len:
+		n, err := strconv.ParseFloat(rule.Param, 64)
+		if err != nil {
+			return false
+		}
+
+		lower, upper := rule.Name != Max, rule.Name != Min
+		switch {
+		case base.IsString():
+			setBounds(&s.MinLength, &s.MaxLength, int(n), lower, upper)
+		case base.IsSlice():
+			setBounds(&s.MinItems, &s.MaxItems, int(n), lower, upper)
+		case base.IsMap():
+			setBounds(&s.MinProperties, &s.MaxProperties, int(n), lower, upper)
+		case base.IsNumber():
+			setBounds(&s.Minimum, &s.Maximum, n, lower, upper)
+		default:
+			return false
+		}
+		return true
+	}
+
+	return false
+}
+
+// setEnum sets the values of the rule Oneof as the enum of the string or the integer, see oneof.
+func setEnum(s *Schema, t Type, values []string) bool {
+	if !t.IsString() && !t.IsInteger() {
+		return false
+	}
+
+	s.Enum = make([]any, 0, len(values))
+	for _, v := range values {
+		if !t.IsInteger() {
+			s.Enum = append(s.Enum, v)
+			continue
+		}
+
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			u, err := strconv.ParseUint(v, 10, 64)
+			if err != nil {
+				return false
+			}
+			s.Enum = append(s.Enum, u)
+			continue
+		}
+		s.Enum = append(s.Enum, n)
+	}
+
+	return true
+}
+
+// atLeast raises the lower bound to n, so the order of the rules does not matter.
+func atLeast(lower **int, n int) {
+	if *lower == nil || **lower < n {
+		*lower = &n
+	}
+}
+
+func setBounds[T any](lower, upper **T, v T, setLower, setUpper bool) {
+	if setLower {
+		*lower = &v
+	}
+
+	if setUpper {
+		*upper = &v
+	}
+}
+
+// typeSchema returns the schema of the Go type.
+func (b schemaBuilder) typeSchema(t Type) *Schema {
+	switch base := t.Deref(); {
+	case base.IsString():
+		return &Schema{Type: "string"}
+	case base.IsBool():
+		return &Schema{Type: "boolean"}
+	case base.IsInteger():
+		return &Schema{Type: "integer"}
+	case base.IsNumber():
+		return &Schema{Type: "number"}
+	case base.IsSlice():
+		return &Schema{Type: "array", Items: b.typeSchema(base.Elem())}
+	case base.IsMap():
+		return &Schema{Type: "object", AdditionalProperties: b.typeSchema(base.Elem())}
+	case b.known[string(base)]:
+		return &Schema{Ref: b.ref(string(base))}
+	}
+
+	return &Schema{}
+}
+
+// jsonName returns the name of the field in JSON and false, when the field is ignored.
+func jsonName(name string, f *ast.Field) (string, bool) {
+	if f.Tag == nil {
+		return name, true
+	}
+
+	unquoted, err := strconv.Unquote(f.Tag.Value)
+	if err != nil {
+		return name, true
+	}
+
+	value := reflect.StructTag(unquoted).Get("json")
+	if value == "-" {
+		return "", false
+	}
+
+	if tagged, _, _ := strings.Cut(value, ","); tagged != "" {
+		return tagged, true
+	}
+
+	return name, true
+}
@@ -0,0 +1,183 @@
+package internal_test
+
+import (
+	"encoding/json"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/paluszkiewiczB/validator/internal"
+)
+
+func Test_JSONSchemas(t *testing.T) {
+	internal.Log = newTestLog(t)
+
+	src := "package example\n" +
+		"type Order struct {\n" +
+		"	ID       string            `json:\"id\" validate:\"required,len=26\"`\n" +
+		"	Status   string            `json:\"status,omitempty\" validate:\"eqfield=ID\"`\n" +
+		"	Email    string            `json:\"email\" validate:\"len=0|min=3\"`\n" +
+		"	Code     string            `validate:\"min=2,max=8,regexp=^[A-Z]+$\"`\n" +
+		"	Kind     string            `json:\"kind\" validate:\"oneof=new 'in progress'\"`\n" +
+		"	Contact  string            `json:\"contact\" validate:\"email\"`\n" +
+		"	Quantity int               `json:\"quantity\" validate:\"min=1,gte=Min,oneof=1 10 100\"`\n" +
+		"	Tags     []string          `json:\"tags\" validate:\"max=5,dive,required,max=10\"`\n" +
+		"	Items    []Item            `json:\"items\" validate:\"required\"`\n" +
+		"	Labels   map[string]string `json:\"labels\"`\n" +
+		"	Min      int               `json:\"-\"`\n" +
+		"	internal string\n" +
+		"}\n" +
+		"type Item struct {\n" +
+		"	Price float64 `json:\"price\" validate:\"min=1.5,max=3\"`\n" +
+		"}\n"
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "example.go", src, 0)
+	if err != nil {
+		t.Fatalf("parsing source: %v", err)
+	}
+
+	structs, err := internal.FindStructs(fset, f)
+	if err != nil {
+		t.Fatalf("finding structs: %v", err)
+	}
+
+	schemas, err := internal.JSONSchemas(structs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string]string{
+		"Order": `{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"$id": "Order.schema.json",
+	"title": "Order",
+	"type": "object",
+	"properties": {
+		"Code": {
+			"type": "string",
+			"minLength": 2,
+			"maxLength": 8,
+			"pattern": "^[A-Z]+$"
+		},
+		"contact": {
+			"type": "string",
+			"format": "email"
+		},
+		"email": {
+			"type": "string",
+			"anyOf": [
+				{
+					"minLength": 0,
+					"maxLength": 0
+				},
+				{
+					"minLength": 3
+				}
+			]
+		},
+		"id": {
+			"type": "string",
+			"minLength": 26,
+			"maxLength": 26
+		},
+		"items": {
+			"type": "array",
+			"minItems": 1,
+			"items": {
+				"$ref": "Item.schema.json"
+			}
+		},
+		"kind": {
+			"type": "string",
+			"enum": [
+				"new",
+				"in progress"
+			]
+		},
+		"labels": {
+			"type": "object",
+			"additionalProperties": {
+				"type": "string"
+			}
+		},
+		"quantity": {
+			"$comment": "rules without JSON Schema equivalent: gte=Min",
+			"type": "integer",
+			"enum": [
+				1,
+				10,
+				100
+			],
+			"minimum": 1
+		},
+		"status": {
+			"$comment": "rules without JSON Schema equivalent: eqfield=ID",
+			"type": "string"
+		},
+		"tags": {
+			"type": "array",
+			"maxItems": 5,
+			"items": {
+				"type": "string",
+				"minLength": 1,
+				"maxLength": 10
+			}
+		}
+	},
+	"required": [
+		"id",
+		"items"
+	]
+}`,
+		"Item": `{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"$id": "Item.schema.json",
+	"title": "Item",
+	"type": "object",
+	"properties": {
+		"price": {
+			"type": "number",
+			"minimum": 1.5,
+			"maximum": 3
+		}
+	}
+}`,
+	}
+
+	if len(schemas) != len(expected) {
+		t.Errorf("expected %d schemas, got %d", len(expected), len(schemas))
+	}
+
+	for name, schema := range expected {
+		got, err := json.MarshalIndent(schemas[name], "", "\t")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if string(got) != schema {
+			t.Errorf("schema of %q expected:\n%s\ngot:\n%s", name, schema, got)
+		}
+	}
+}
+
+func Test_JSONSchemas_UnknownRule(t *testing.T) {
+	internal.Log = newTestLog(t)
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "example.go", "package example\ntype User struct {\n\tID string `validate:\"required,uuid\"`\n}\n", 0)
+	if err != nil {
+		t.Fatalf("parsing source: %v", err)
+	}
+
+	structs, err := internal.FindStructs(fset, f)
+	if err != nil {
+		t.Fatalf("finding structs: %v", err)
+	}
+
+	_, err = internal.JSONSchemas(structs)
+	if err == nil || !strings.Contains(err.Error(), `validator not found for struct: "User", field: "ID", validation: "uuid"`) {
+		t.Errorf("expected error of the unknown rule, got: %v", err)
+	}
+}
@@ -0,0 +1,130 @@
+package internal
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/exp/maps"
+)
+
+// MarkerPrefix is the prefix of the doc comment markers with the rules, an alternative to the validate tag:
+//
+//	// +validate:required
+//	// +validate:min=3,create:max=10
+//	Name string
+//
+// The value of the marker follows the grammar of the value of the validate tag. Markers of the named type
+// apply to all the fields of the type and of the pointers to the type, before the markers of the field.
+const MarkerPrefix = "+validate:"
+
+// ParseMarkers parses the Groups of the markers in the comment groups, in order. Nil comment groups are skipped.
+func ParseMarkers(fset *token.FileSet, docs ...*ast.CommentGroup) (Groups, error) {
+	return ParseMarkersOf(fset, CurrentSyntax(), docs...)
+}
+
+// ParseMarkersOf is like ParseMarkers, but it parses the rules with the syntax instead of CurrentSyntax.
+func ParseMarkersOf(fset *token.FileSet, syntax Syntax, docs ...*ast.CommentGroup) (Groups, error) {
+	p := &tagParser{groups: Groups{}, syntax: syntax}
+	for _, doc := range docs {
+		if doc == nil {
+			continue
+		}
+
+		for _, c := range doc.List {
+			text, ok := strings.CutPrefix(c.Text, "//")
+			if !ok {
+				continue
+			}
+
+			trimmed := strings.TrimLeft(text, " \t")
+			value, ok := strings.CutPrefix(strings.TrimRight(trimmed, " \t"), MarkerPrefix)
+			if !ok {
+				continue
+			}
+
+			pos := fset.Position(c.Pos())
+			skipped := len("//") + len(text) - len(trimmed) + len(MarkerPrefix)
+			pos.Offset += skipped
+			pos.Column += skipped
+
+			rs := make(tagRunes, 0, len(value))
+			for _, r := range value {
+				rs = append(rs, tagRune{r: r, pos: pos})
+				pos.Offset += utf8.RuneLen(r)
+				pos.Column += utf8.RuneLen(r)
+			}
+
+			if len(rs) == 0 {
+				return nil, &TagError{Pos: pos, Msg: "expected rule after marker " + MarkerPrefix}
+			}
+
+			if err := p.value(DefaultGroup, rs, tagRune{pos: pos}); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return p.groups, nil
+}
+
+// MergeMarkers returns the rules of the markers followed by the rules of the tag, per group.
+// The rule of the marker conflicts with the rule of the tag with the same name and the different parameter,
+// the same rules are merged into one.
+func MergeMarkers(markers, tag Groups) (Groups, error) {
+	out := Groups{}
+	for group, vals := range markers {
+		out[group] = append(out[group], vals...)
+	}
+
+	groups := maps.Keys(tag)
+	slices.Sort(groups)
+	for _, group := range groups {
+	alternatives:
+		for _, alts := range tag[group] {
+			for _, marked := range markers[group] {
+				if marked.Tag() != alts.Tag() {
+					continue
+				}
+
+				if marked.String() != alts.String() {
+					return nil, &TagError{Pos: marked[0].Pos, Msg: fmt.Sprintf("rule %q of the marker conflicts with rule %q of the tag", marked, alts)}
+				}
+
+				continue alternatives
+			}
+
+			out[group] = append(out[group], alts)
+		}
+	}
+
+	return out, nil
+}
+
+// typeMarkers returns the Groups of the markers of the named types declared in the file, by the name of the type.
+func typeMarkers(fset *token.FileSet, f *ast.File, syntax Syntax) (map[string]Groups, error) {
+	out := map[string]Groups{}
+	for _, decl := range f.Decls {
+		d, ok := decl.(*ast.GenDecl)
+		if !ok || d.Tok != token.TYPE {
+			continue
+		}
+
+		for _, spec := range d.Specs {
+			t := spec.(*ast.TypeSpec)
+			groups, err := ParseMarkersOf(fset, syntax, typeDoc(d, t))
+			if err != nil {
+				return nil, fmt.Errorf("type: %q, %w", t.Name.Name, err)
+			}
+
+			if len(groups) != 0 {
+				out[t.Name.Name] = groups
+			}
+		}
+	}
+
+	return out, nil
+}
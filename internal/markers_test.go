@@ -0,0 +1,102 @@
+package internal_test
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/paluszkiewiczB/validator/internal"
+)
+
+func Test_Markers(t *testing.T) {
+	internal.Log = newTestLog(t)
+
+	src := "package example\n" +
+		"// +validate:min=3\n" +
+		"type Name string\n" +
+		"type User struct {\n" +
+		"	// Login of the user.\n" +
+		"	// +validate:required\n" +
+		"	// +validate:create:max=10\n" +
+		"	Login string `validate:\"max=20\"`\n" +
+		"	First Name `validate:\"min=3\"` // +validate:max=5\n" +
+		"	Email *string // +validate:required\n" +
+		"	Last  *Name\n" +
+		"}\n"
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "example.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("parsing source: %v", err)
+	}
+
+	structs, err := internal.FindStructs(fset, f)
+	if err != nil {
+		t.Fatalf("finding structs: %v", err)
+	}
+
+	expected := map[string]string{
+		"Login": "required,max=20",
+		"First": "min=3,max=5",
+		"Email": "required",
+		"Last":  "min=3",
+	}
+
+	if len(structs) != 1 || len(structs[0].Fields) != len(expected) {
+		t.Fatalf("expected struct User with %d fields, got: %+v", len(expected), structs)
+	}
+
+	for _, field := range structs[0].Fields {
+		if got := field.Validations.String(); got != expected[field.Name] {
+			t.Errorf("field %q: expected validations %q, got: %q", field.Name, expected[field.Name], got)
+		}
+	}
+
+	if got := structs[0].Fields[0].Groups["create"].String(); got != "max=10" {
+		t.Errorf("expected validations of group create %q, got: %q", "max=10", got)
+	}
+}
+
+func Test_Markers_Errors(t *testing.T) {
+	internal.Log = newTestLog(t)
+
+	for name, c := range map[string]struct {
+		src string
+		err string
+	}{
+		"conflict": {
+			src: "package example\n" +
+				"type User struct {\n" +
+				"	// +validate:required,min=3\n" +
+				"	Login string `validate:\"min=5\"`\n" +
+				"}\n",
+			err: `struct: "User", field: "Login", example.go:3:24: rule "min=3" of the marker conflicts with rule "min=5" of the tag`,
+		},
+		"syntax": {
+			src: "package example\n" +
+				"type User struct {\n" +
+				"	Login string // +validate:required,,min=3\n" +
+				"}\n",
+			err: `struct: "User", field: "Login", parsing markers: example.go:3:37: expected rule before ','`,
+		},
+		"empty": {
+			src: "package example\n" +
+				"type User struct {\n" +
+				"	Login string // +validate:\n" +
+				"}\n",
+			err: `struct: "User", field: "Login", parsing markers: example.go:3:28: expected rule after marker +validate:`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			fset := token.NewFileSet()
+			f, err := parser.ParseFile(fset, "example.go", c.src, parser.ParseComments)
+			if err != nil {
+				t.Fatalf("parsing source: %v", err)
+			}
+
+			if _, err := internal.FindStructs(fset, f); err == nil || err.Error() != c.err {
+				t.Errorf("expected error:\n%s\ngot:\n%v", c.err, err)
+			}
+		})
+	}
+}
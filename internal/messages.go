@@ -0,0 +1,185 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Placeholders of the message templates.
+// Field and param are replaced at generation time, value is formatted when the validation fails.
+const (
+	PlaceholderField = "{field}"
+	PlaceholderParam = "{param}"
+	PlaceholderValue = "{value}"
+)
+
+// MessageTagKey is the struct tag key of the message templates. For field:
+//
+//	Name string `validate:"required,eqfield=Other" msg:"{field} is invalid" msg.required:"{field} is required"`
+//
+// the message of 'required' is "{field} is required", and the message of 'eqfield' is "{field} is invalid".
+const MessageTagKey = "msg"
+
+// Messages are the templates of the error messages per validation key.
+type Messages map[string]string
+
+// DefaultMessages are the templates used when neither the field nor Templates override the message.
+var DefaultMessages = Messages{
+	Required: `field "{field}" is required`,
+	Eqfield:  `field "{field}" must be equal to "{param}"`,
+	Gte:      `field "{field}" must be greater than or equal to "{param}"`,
+	Min:      `field "{field}" must be at least {param}`,
+	Max:      `field "{field}" must be at most {param}`,
+	Len:      `field "{field}" must have length {param}`,
+	Oneof:    `field "{field}" must be one of: {param}`,
+	Regexp:   `field "{field}" must match "{param}"`,
+	Email:    `field "{field}" must be an email address`,
+	URL:      `field "{field}" must be a URL`,
+}
+
+// fallbackMessage is the template of validation key not found in DefaultMessages.
+const fallbackMessage = `field "{field}" is invalid`
+
+// Templates override DefaultMessages per validation key, see UseMessages.
+var Templates = Messages{}
+
+// UseMessages reads the templates from the JSON file with the object of validation keys to the templates
+// and merges them into Templates, the templates of the file take precedence, e.g. over the messages of the config:
+//
+//	{"required": "{field} is required"}
+func UseMessages(path string) error {
+	msgs, err := ReadMessages(path)
+	if err != nil {
+		return err
+	}
+
+	Templates = Templates.With(msgs)
+	return nil
+}
+
+// With returns the Messages with the other messages merged into them, the other messages take precedence.
+func (m Messages) With(other Messages) Messages {
+	return mergeMaps(m, other)
+}
+
+// ReadMessages reads the templates from the JSON file, see UseMessages.
+func ReadMessages(path string) (Messages, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading messages: %w", err)
+	}
+
+	msgs := Messages{}
+	if err = json.Unmarshal(content, &msgs); err != nil {
+		return nil, fmt.Errorf("parsing messages: %q, %w", path, err)
+	}
+
+	return msgs, nil
+}
+
+// Message returns the template of the error message of the validation key for the field of the struct.
+// Templates are resolved in order: msg.<key> tag of the field, msg tag of the field, Templates of the struct,
+// DefaultMessages.
+func Message(key string, str Struct, field Field) string {
+	for _, msg := range []string{field.Messages[key], field.Messages[""], str.Templates[key], DefaultMessages[key]} {
+		if msg != "" {
+			return msg
+		}
+	}
+
+	return fallbackMessage
+}
+
+// ParseMessages parses the message templates of the field tag, see MessageTagKey.
+// The empty key is the template of all the validations of the field.
+// Alternatives can be overridden with the tag of all of them, e.g. `msg.rgb|rgba:"{field} must be a color"`.
+func ParseMessages(tag string, vals ...Validations) (Messages, error) {
+	unquoted, err := strconv.Unquote(tag)
+	if err != nil {
+		return nil, fmt.Errorf("unquoting tag: %q, %w", tag, err)
+	}
+
+	st := reflect.StructTag(unquoted)
+	msgs := Messages{}
+	if msg, ok := st.Lookup(MessageTagKey); ok {
+		msgs[""] = msg
+	}
+
+	for _, v := range vals {
+		for _, alts := range v {
+			keys := mapSlice(alts, func(r Rule) string { return r.Name })
+			if len(alts) > 1 {
+				keys = append(keys, alts.Tag())
+			}
+
+			for _, key := range keys {
+				if msg, ok := st.Lookup(MessageTagKey + "." + key); ok {
+					msgs[key] = msg
+				}
+			}
+		}
+	}
+
+	return msgs, nil
+}
+
+// alternativesMessage returns the template of the error message of the alternatives, with resolved PlaceholderParam.
+// Message of multiple alternatives joins the messages of the rules with 'or', unless the field overrides it
+// with the msg tag of all the alternatives or the msg tag of the field.
+func alternativesMessage(alts Alternatives, str Struct, field Field) string {
+	if len(alts) == 1 {
+		return resolveParam(Message(alts[0].Name, str, field), alts[0].Param)
+	}
+
+	for _, msg := range []string{field.Messages[alts.Tag()], field.Messages[""]} {
+		if msg != "" {
+			return msg
+		}
+	}
+
+	return strings.Join(mapSlice(alts, func(r Rule) string {
+		return resolveParam(Message(r.Name, str, field), r.Param)
+	}), " or ")
+}
+
+func resolveParam(template, param string) string {
+	return strings.ReplaceAll(template, PlaceholderParam, param)
+}
+
+func resolveField(template string, field Field) string {
+	return strings.ReplaceAll(template, PlaceholderField, field.Reported())
+}
+
+// messageExpr returns the expression of the message with resolved PlaceholderField and PlaceholderParam.
+// When the message contains PlaceholderValue, the value of the field is formatted with fmt.
+func messageExpr(msg string, str Struct, field Field) (ast.Expr, []string) {
+	if !strings.Contains(msg, PlaceholderValue) {
+		return &ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(msg)}, nil
+	}
+
+	parts := strings.Split(msg, PlaceholderValue)
+	format := make([]string, len(parts))
+	args := make([]string, len(parts)-1)
+	for i, part := range parts {
+		format[i] = strings.ReplaceAll(part, "%", "%%")
+	}
+
+	for i := range args {
+		args[i] = FieldAccess(str, field)
+	}
+
+	return &ast.CallExpr{
+		Fun:  &ast.Ident{Name: "fmt.Sprintf"},
+		Args: append([]ast.Expr{&ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(strings.Join(format, "%v"))}}, mapSlice(args, toIdent)...),
+	}, []string{"fmt"}
+}
+
+func toIdent(name string) ast.Expr {
+	return &ast.Ident{Name: name}
+}
@@ -0,0 +1,309 @@
+package internal
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"strconv"
+	"strings"
+)
+
+// ModTagKey is the struct tag key of the modifiers of go-playground/mold, e.g. `mod:"trim,lcase,default=20"`.
+// Modifiers follow the grammar of the tag value of the rules, without the groups and the alternatives.
+// They are applied in the order of the tag by the generated method Normalize, see NormalizeMethod.
+const ModTagKey = "mod"
+
+const (
+	Trim    = "trim"
+	Ltrim   = "ltrim"
+	Rtrim   = "rtrim"
+	Lcase   = "lcase"
+	Ucase   = "ucase"
+	Default = "default"
+)
+
+// Modified is the generated statement modifying the field.
+type Modified struct {
+	Stmt ast.Stmt
+	// Imports are the import paths required by the generated Stmt
+	Imports []string
+	// NilSafe is true, when the Stmt handles the nil pointer field itself, so it is not guarded by the nil check
+	NilSafe bool
+}
+
+// ModifierFunc generates the statement modifying the field, access is the expression of the value of the field,
+// which is the dereferenced pointer for the pointer fields.
+type ModifierFunc func(rule Rule, str Struct, field Field, access string) (Modified, error)
+
+var modifiers = map[string]ModifierFunc{
+	Trim:    modOptions(0, replaceString("strings.TrimSpace(%s)", "strings")),
+	Ltrim:   modOptions(0, replaceString("strings.TrimLeftFunc(%s, unicode.IsSpace)", "strings", "unicode")),
+	Rtrim:   modOptions(0, replaceString("strings.TrimRightFunc(%s, unicode.IsSpace)", "strings", "unicode")),
+	Lcase:   modOptions(0, replaceString("strings.ToLower(%s)", "strings")),
+	Ucase:   modOptions(0, replaceString("strings.ToUpper(%s)", "strings")),
+	Default: modOptions(1, defaultValue),
+}
+
+// ModifierFor returns the ModifierFunc of the modifier, or nil when the modifier is unknown.
+func ModifierFor(modifier string) ModifierFunc {
+	return modifiers[modifier]
+}
+
+// ParseModifiers parses the modifiers of the ModTagKey in the struct tag literal, at the position pos in the source.
+func ParseModifiers(tag string, pos token.Position) ([]Rule, error) {
+	groups, err := parseTag(tag, pos, Syntax{TagKeys: []string{ModTagKey}})
+	if err != nil {
+		return nil, err
+	}
+
+	for group, vals := range groups {
+		if group != DefaultGroup && len(vals) != 0 {
+			return nil, &TagError{Pos: vals[0][0].Pos, Msg: fmt.Sprintf("modifiers can not declare groups, got: %q", group)}
+		}
+	}
+
+	var rules []Rule
+	for _, alts := range groups[DefaultGroup] {
+		if len(alts) != 1 {
+			return nil, &TagError{Pos: alts[1].Pos, Msg: fmt.Sprintf("modifiers can not be alternatives, got: %q", alts.Tag())}
+		}
+
+		rules = append(rules, alts[0])
+	}
+
+	return rules, nil
+}
+
+// HasModifiers returns true, when any field of the struct has the modifiers.
+func (s Struct) HasModifiers() bool {
+	for _, f := range s.Fields {
+		if len(f.Modifiers) != 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NormalizeMethod returns the name of the generated method modifying the fields of the struct.
+func NormalizeMethod(str Struct) string {
+	if str.Unexported {
+		return "normalize"
+	}
+
+	return "Normalize"
+}
+
+// NormalizeAndValidateMethod returns the name of the generated method modifying and then validating the struct.
+func NormalizeAndValidateMethod(str Struct) string {
+	if str.Unexported {
+		return "normalizeAndValidate"
+	}
+
+	return "NormalizeAndValidate"
+}
+
+// normalizeMethods generates the methods Normalize and NormalizeAndValidate of the struct. They always have the pointer
+// receiver, the value receiver would modify the copy.
+func normalizeMethods(str Struct) ([]ast.Decl, []string, error) {
+	var stmts []ast.Stmt
+	var imports []string
+	for _, field := range str.Fields {
+		fieldStmts, imps, err := modifierStmts(str, field)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		stmts = append(stmts, fieldStmts...)
+		imports = append(imports, imps...)
+	}
+
+	str.Receiver = PointerReceiver
+	recv := &ast.FieldList{List: []*ast.Field{{Type: &ast.Ident{Name: Receiver(str)}}}}
+	return []ast.Decl{
+		&ast.FuncDecl{
+			Doc: &ast.CommentGroup{
+				List: []*ast.Comment{
+					{Text: "// " + NormalizeMethod(str) + " modifies the fields with the modifiers of the " + ModTagKey + " tag."},
+				},
+			},
+			Recv: recv,
+			Name: &ast.Ident{Name: NormalizeMethod(str)},
+			Type: &ast.FuncType{},
+			Body: &ast.BlockStmt{List: stmts},
+		},
+		&ast.FuncDecl{
+			Doc: &ast.CommentGroup{
+				List: []*ast.Comment{
+					{Text: "// " + NormalizeAndValidateMethod(str) + " modifies the fields and validates the struct."},
+				},
+			},
+			Recv: recv,
+			Name: &ast.Ident{Name: NormalizeAndValidateMethod(str)},
+			Type: &ast.FuncType{Results: &ast.FieldList{List: []*ast.Field{{Type: &ast.Ident{Name: "error"}}}}},
+			Body: &ast.BlockStmt{List: []ast.Stmt{
+				&ast.ExprStmt{X: &ast.CallExpr{Fun: &ast.Ident{Name: ReceiverName(str) + "." + NormalizeMethod(str)}}},
+				&ast.ReturnStmt{Results: []ast.Expr{&ast.CallExpr{Fun: &ast.Ident{Name: ReceiverName(str) + "." + ValidateMethod(str)}}}},
+			}},
+		},
+	}, imports, nil
+}
+
+// modifierStmts generates the statements modifying the field in the order of its modifiers.
+// Modifiers of the pointer field modify the pointed value, when the pointer is not nil, except for the NilSafe ones.
+func modifierStmts(str Struct, field Field) ([]ast.Stmt, []string, error) {
+	access := FieldAccess(str, field)
+	if field.Type.IsPtr() {
+		access = "*" + access
+	}
+
+	var stmts, guarded []ast.Stmt
+	var imports []string
+	guard := func() {
+		if len(guarded) != 0 {
+			stmts = append(stmts, &ast.IfStmt{
+				Cond: &ast.Ident{Name: FieldAccess(str, field) + " != nil"},
+				Body: &ast.BlockStmt{List: guarded},
+			})
+		}
+
+		guarded = nil
+	}
+
+	for _, rule := range field.Modifiers {
+		mod := ModifierFor(rule.Name)
+		if mod == nil {
+			return nil, nil, fmt.Errorf("%s: modifier not found for struct: %q, field: %q, modifier: %q", rule.Pos, str.Name, field.Name, rule.Name)
+		}
+
+		modified, err := mod(rule, str, field, access)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", rule.Pos, err)
+		}
+
+		imports = append(imports, modified.Imports...)
+		if !field.Type.IsPtr() {
+			stmts = append(stmts, modified.Stmt)
+			continue
+		}
+
+		if modified.NilSafe {
+			guard()
+			stmts = append(stmts, modified.Stmt)
+			continue
+		}
+
+		guarded = append(guarded, modified.Stmt)
+	}
+
+	guard()
+	return stmts, imports, nil
+}
+
+func modOptions(count int, fun ModifierFunc) ModifierFunc {
+	return func(rule Rule, str Struct, field Field, access string) (Modified, error) {
+		got := 0
+		if rule.Param != "" {
+			got = 1
+		}
+
+		if got != count {
+			return Modified{}, fmt.Errorf("modifier %q expects exactly %d option, but got: %d - %q", rule.Name, count, got, rule.Param)
+		}
+
+		return fun(rule, str, field, access)
+	}
+}
+
+// replaceString returns the modifier assigning the call of the format, e.g. 'strings.TrimSpace(%s)', to the string field.
+func replaceString(format string, imports ...string) ModifierFunc {
+	return func(rule Rule, str Struct, field Field, access string) (Modified, error) {
+		if field.Type.Deref() != "string" {
+			return Modified{}, fmt.Errorf("unsupported type for modifier: %q, type: %q", rule.Name, field.Type)
+		}
+
+		return Modified{
+			Stmt: &ast.AssignStmt{
+				Lhs: []ast.Expr{&ast.Ident{Name: access}},
+				Tok: token.ASSIGN,
+				Rhs: []ast.Expr{&ast.Ident{Name: fmt.Sprintf(format, access)}},
+			},
+			Imports: imports,
+		}, nil
+	}
+}
+
+// defaultValue sets the zero value of the string or the number to the param. The nil pointer field is set
+// to the pointer to the param, the pointed value is not modified.
+func defaultValue(rule Rule, str Struct, field Field, access string) (Modified, error) {
+	t := field.Type
+	if t.IsPtr() {
+		t = t[1:]
+	}
+
+	value := rule.Param
+	zero := "0"
+	p, isParam := str.TypeParam(t)
+	switch {
+	case t.IsPtr():
+		return Modified{}, fmt.Errorf("unsupported type for modifier: %q, type: %q", rule.Name, field.Type)
+	case isParam && p.Integer(), t.IsInteger():
+		if err := defaultConstant(rule, t, token.INT, isParam); err != nil {
+			return Modified{}, err
+		}
+	case isParam && p.Number(), t.IsNumber():
+		if err := defaultConstant(rule, t, token.FLOAT, isParam); err != nil {
+			return Modified{}, err
+		}
+	case isParam:
+		return Modified{}, fmt.Errorf("unsupported type for modifier: %q, type parameter %s %s is not a number", rule.Name, p.Name, p.Constraint)
+	case t.IsString():
+		value, zero = strconv.Quote(rule.Param), `""`
+	default:
+		return Modified{}, fmt.Errorf("unsupported type for modifier: %q, type: %q", rule.Name, field.Type)
+	}
+
+	assign := func(lhs, rhs string) ast.Stmt {
+		return &ast.AssignStmt{Lhs: []ast.Expr{&ast.Ident{Name: lhs}}, Tok: token.ASSIGN, Rhs: []ast.Expr{&ast.Ident{Name: rhs}}}
+	}
+
+	if field.Type.IsPtr() {
+		ptr := FieldAccess(str, field)
+		return Modified{
+			Stmt: &ast.IfStmt{
+				Cond: &ast.BinaryExpr{X: &ast.Ident{Name: ptr}, Op: token.EQL, Y: &ast.Ident{Name: "nil"}},
+				Body: &ast.BlockStmt{List: []ast.Stmt{assign(ptr, "new("+string(t)+")"), assign("*"+ptr, value)}},
+			},
+			NilSafe: true,
+		}, nil
+	}
+
+	return Modified{
+		Stmt: &ast.IfStmt{
+			Cond: &ast.BinaryExpr{X: &ast.Ident{Name: access}, Op: token.EQL, Y: &ast.Ident{Name: zero}},
+			Body: &ast.BlockStmt{List: []ast.Stmt{assign(access, value)}},
+		},
+	}, nil
+}
+
+// defaultConstant returns the error, when the param of the default modifier is not the constant of the kind,
+// the token.INT or token.FLOAT, or it overflows the predeclared type t, e.g. 300 of uint8.
+// The constants of the type parameters are only parsed, the range of their types is not known.
+func defaultConstant(rule Rule, t Type, kind token.Token, isParam bool) error {
+	expects := map[token.Token]string{token.INT: "integer", token.FLOAT: "number"}[kind]
+	lit := strings.TrimPrefix(rule.Param, "-")
+	if constant.MakeFromLiteral(lit, kind, 0).Kind() == constant.Unknown {
+		return fmt.Errorf("modifier %q expects %s, got: %q", rule.Name, expects, rule.Param)
+	}
+
+	if isParam {
+		return nil
+	}
+
+	if !constantFits(t, rule.Param) {
+		return fmt.Errorf("modifier %q value %s overflows type %q", rule.Name, rule.Param, t)
+	}
+
+	return nil
+}
@@ -0,0 +1,138 @@
+package internal_test
+
+import (
+	"go/parser"
+	"go/token"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/paluszkiewiczB/validator/internal"
+)
+
+func Test_ParseModifiers(t *testing.T) {
+	mods, err := internal.ParseModifiers("`json:\"size\" mod:\"trim,default=20\" validate:\"max=100\"`", token.Position{Line: 1, Column: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{"trim", "default=20"}
+	if got := mapRules(mods); !reflect.DeepEqual(expected, got) {
+		t.Errorf("expected modifiers %q, got: %q", expected, got)
+	}
+
+	for tag, msg := range map[string]string{
+		"`mod:\"trim|lcase\"`":        `modifiers can not be alternatives, got: "trim|lcase"`,
+		"`mod:\"create:trim\"`":       `modifiers can not declare groups, got: "create"`,
+		"`mod.create:\"trim\"`":       `modifiers can not declare groups, got: "create"`,
+		"`mod:\"trim,\"`":             `expected rule after ','`,
+		"`mod:\"trim\" mod:\"trim\"`": `duplicated tag key "mod"`,
+	} {
+		t.Run(tag, func(t *testing.T) {
+			_, err := internal.ParseModifiers(tag, token.Position{Line: 1, Column: 1})
+			if err == nil || !strings.Contains(err.Error(), msg) {
+				t.Errorf("expected error %q, got: %v", msg, err)
+			}
+		})
+	}
+}
+
+func mapRules(rules []internal.Rule) []string {
+	out := make([]string, len(rules))
+	for i, r := range rules {
+		out[i] = r.String()
+	}
+
+	return out
+}
+
+const modifierSource = `package example
+
+type Search struct {
+	Query string  ` + "`" + `mod:"trim,lcase" validate:"required"` + "`" + `
+	Email *string ` + "`" + `mod:"ltrim,ucase"` + "`" + `
+	Size  int     ` + "`" + `mod:"default=20"` + "`" + `
+	Ratio float64 ` + "`" + `mod:"default=0.5"` + "`" + `
+	Sort  string  ` + "`" + `mod:"default=name asc"` + "`" + `
+	Limit *uint8  ` + "`" + `mod:"default=10"` + "`" + `
+	Name  *string ` + "`" + `mod:"trim,default=anonymous,ucase"` + "`" + `
+}
+
+//validator:unexported
+type Secret struct {
+	Value string ` + "`" + `mod:"trim"` + "`" + `
+}
+`
+
+func Test_GenerateFile_Modifiers(t *testing.T) {
+	internal.Log = newTestLog(t)
+
+	out := generate(t, modifierSource)
+	for _, expected := range []string{
+		"func (s *Search) Validate() error {",
+		"// Normalize modifies the fields with the modifiers of the mod tag.\nfunc (s *Search) Normalize() {",
+		"\ts.Query = strings.TrimSpace(s.Query)\n\ts.Query = strings.ToLower(s.Query)\n",
+		"if s.Email != nil {\n\t\t*s.Email = strings.TrimLeftFunc(*s.Email, unicode.IsSpace)\n\t\t*s.Email = strings.ToUpper(*s.Email)\n\t}",
+		"if s.Size == 0 {\n\t\ts.Size = 20\n\t}",
+		"if s.Ratio == 0 {\n\t\ts.Ratio = 0.5\n\t}",
+		"if s.Sort == \"\" {\n\t\ts.Sort = \"name asc\"\n\t}",
+		"if s.Limit == nil {\n\t\ts.Limit = new(uint8)\n\t\t*s.Limit = 10\n\t}",
+		"if s.Name != nil {\n\t\t*s.Name = strings.TrimSpace(*s.Name)\n\t}\n\tif s.Name == nil {\n\t\ts.Name = new(string)\n\t\t*s.Name = \"anonymous\"\n\t}\n\tif s.Name != nil {\n\t\t*s.Name = strings.ToUpper(*s.Name)\n\t}",
+		"func (s *Search) NormalizeAndValidate() error {\n\ts.Normalize()\n\treturn s.Validate()\n}",
+		"func (s *Secret) validate() error {\n\treturn nil\n}",
+		"func (s *Secret) normalize() {",
+		"func (s *Secret) normalizeAndValidate() error {\n\ts.normalize()\n\treturn s.validate()\n}",
+	} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("expected %q in:\n%s", expected, out)
+		}
+	}
+}
+
+func Test_GenerateFile_Modifiers_ValueReceiver(t *testing.T) {
+	internal.Log = newTestLog(t)
+	internal.Receivers = internal.ValueReceiver
+	t.Cleanup(func() { internal.Receivers = internal.AutoReceiver })
+
+	out := generate(t, modifierSource)
+	for _, expected := range []string{"func (s Search) Validate() error {", "func (s *Search) Normalize() {"} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("expected %q in:\n%s", expected, out)
+		}
+	}
+}
+
+func Test_GenerateFile_Modifiers_Errors(t *testing.T) {
+	internal.Log = newTestLog(t)
+
+	for src, msg := range map[string]string{
+		"type Box struct {\n\tV int `mod:\"trim\"`\n}":               `unsupported type for modifier: "trim", type: "int"`,
+		"type Box struct {\n\tV []string `mod:\"lcase\"`\n}":         `unsupported type for modifier: "lcase", type: "[]string"`,
+		"type Box struct {\n\tV **int `mod:\"default=1\"`\n}":        `unsupported type for modifier: "default", type: "**int"`,
+		"type Box struct {\n\tV uint8 `mod:\"default=300\"`\n}":      `example.go:4:16: modifier "default" value 300 overflows type "uint8"`,
+		"type Box struct {\n\tV uint `mod:\"default=-1\"`\n}":        `modifier "default" value -1 overflows type "uint"`,
+		"type Box struct {\n\tV float32 `mod:\"default=1e39\"`\n}":   `modifier "default" value 1e39 overflows type "float32"`,
+		"type Box struct {\n\tV int `mod:\"default=0.5\"`\n}":        `modifier "default" expects integer, got: "0.5"`,
+		"type Box struct {\n\tV string `mod:\"trim=all\"`\n}":        `modifier "trim" expects exactly 0 option, but got: 1 - "all"`,
+		"type Box struct {\n\tV string `mod:\"title\"`\n}":           `modifier not found for struct: "Box", field: "V", modifier: "title"`,
+		"type Box[T ~string] struct {\n\tV T `mod:\"default=1\"`\n}": `type parameter T ~string is not a number`,
+	} {
+		t.Run(src, func(t *testing.T) {
+			fset := token.NewFileSet()
+			f, err := parser.ParseFile(fset, "example.go", "package example\n\n"+src, parser.AllErrors)
+			if err != nil {
+				t.Fatalf("parsing source: %v", err)
+			}
+
+			structs, err := internal.FindStructs(fset, f)
+			if err != nil {
+				t.Fatalf("finding structs: %v", err)
+			}
+
+			_, err = internal.GenerateFile(structs, "example")
+			if err == nil || !strings.Contains(err.Error(), msg) {
+				t.Errorf("expected error %q, got: %v", msg, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package internal
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// NameTag is the struct tag key with the names of the fields reported in the errors, e.g. 'json', 'form' or 'yaml'.
+// When empty, the Go names of the fields are reported.
+var NameTag = ""
+
+// ParseName returns the name of the field from the tag with the key, e.g. 'string_pointer' for key 'json' and tag:
+//
+//	`json:"string_pointer,omitempty" validate:"required"`
+//
+// Options after the comma are ignored. It returns an empty string when the tag is not present,
+// the name is empty (`json:",omitempty"`) or the field is ignored (`json:"-"`).
+// Like in encoding/json, `json:"-,"` is the name '-'.
+func ParseName(tag, key string) (string, error) {
+	unquoted, err := strconv.Unquote(tag)
+	if err != nil {
+		return "", fmt.Errorf("unquoting tag: %q, %w", tag, err)
+	}
+
+	value, ok := reflect.StructTag(unquoted).Lookup(key)
+	if !ok {
+		return "", nil
+	}
+
+	if value == "-" {
+		return "", nil
+	}
+
+	name, _, _ := strings.Cut(value, ",")
+	return name, nil
+}
@@ -0,0 +1,208 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"golang.org/x/exp/maps"
+)
+
+// OpenAPIVersion is the version of OpenAPI documents created by MergeOpenAPI.
+const OpenAPIVersion = "3.1.0"
+
+// OpenAPIRef returns the reference to the component schema of the struct.
+func OpenAPIRef(name string) string {
+	return "#/components/schemas/" + name
+}
+
+// OpenAPISchemas returns the component schemas of the structs, keyed by the struct name.
+// Unlike JSONSchemas, nested structs refer to the components with OpenAPIRef, pointers which are not required
+// are nullable and the descriptions are taken from the doc comments of the types and fields.
+func OpenAPISchemas(structs []Struct) (map[string]*Schema, error) {
+	known := make(map[string]bool, len(structs))
+	for _, s := range structs {
+		known[s.Name] = true
+	}
+
+	b := schemaBuilder{ref: OpenAPIRef, known: known, nullable: true, describe: true}
+	out := make(map[string]*Schema, len(structs))
+	for _, str := range structs {
+		s, err := b.object(str)
+		if err != nil {
+			return nil, fmt.Errorf("struct: %q, %w", str.Name, err)
+		}
+
+		out[str.Name] = s
+	}
+
+	return out, nil
+}
+
+// MergeOpenAPI sets the schemas in components.schemas of the OpenAPI document in JSON and returns the merged document.
+// Other parts of the document and the other component schemas are kept as they are: in the same order and with
+// the same literals, only the indentation is normalized. New schemas are appended in the order of their names.
+// When the document is empty, a new one is created with the title.
+func MergeOpenAPI(doc []byte, title string, schemas map[string]*Schema) ([]byte, error) {
+	var root jsonObject
+	if len(bytes.TrimSpace(doc)) == 0 {
+		info, err := json.Marshal(map[string]string{"title": title, "version": "0.0.0"})
+		if err != nil {
+			return nil, fmt.Errorf("encoding OpenAPI info: %w", err)
+		}
+
+		root = jsonObject{{key: "openapi", value: json.RawMessage(strconv.Quote(OpenAPIVersion))}, {key: "info", value: info}}
+	} else {
+		var err error
+		if root, err = decodeObject(doc); err != nil {
+			return nil, fmt.Errorf("decoding OpenAPI document: %w", err)
+		}
+	}
+
+	components, err := root.object("components")
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := components.object("schemas")
+	if err != nil {
+		return nil, fmt.Errorf("components: %w", err)
+	}
+
+	names := maps.Keys(schemas)
+	slices.Sort(names)
+	for _, name := range names {
+		s, err := json.Marshal(schemas[name])
+		if err != nil {
+			return nil, fmt.Errorf("encoding schema: %q, %w", name, err)
+		}
+
+		existing.set(name, s)
+	}
+
+	if err = components.setObject("schemas", existing); err != nil {
+		return nil, err
+	}
+
+	if err = root.setObject("components", components); err != nil {
+		return nil, err
+	}
+
+	compact, err := root.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	if err = json.Indent(&out, compact, "", "\t"); err != nil {
+		return nil, fmt.Errorf("encoding OpenAPI document: %w", err)
+	}
+
+	return append(out.Bytes(), '\n'), nil
+}
+
+// jsonObject is the JSON object with its members in the order of the document, values are kept as they are.
+type jsonObject []jsonMember
+
+type jsonMember struct {
+	key   string
+	value json.RawMessage
+}
+
+// decodeObject decodes the JSON object, keeping the order and the literals of its members.
+func decodeObject(data []byte) (jsonObject, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil, fmt.Errorf("expected object, got: %v %v", tok, err)
+	}
+
+	var o jsonObject
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		var value json.RawMessage
+		if err = dec.Decode(&value); err != nil {
+			return nil, err
+		}
+
+		o = append(o, jsonMember{key: tok.(string), value: value})
+	}
+
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+
+	if dec.More() {
+		return nil, errors.New("unexpected data after object")
+	}
+
+	return o, nil
+}
+
+// object returns the JSON object under the key, or empty object if absent.
+func (o jsonObject) object(key string) (jsonObject, error) {
+	for _, m := range o {
+		if m.key != key || string(m.value) == "null" {
+			continue
+		}
+
+		child, err := decodeObject(m.value)
+		if err != nil {
+			return nil, fmt.Errorf("expected object at %q: %w", key, err)
+		}
+
+		return child, nil
+	}
+
+	return jsonObject{}, nil
+}
+
+// set replaces the value of the key in place, or appends it.
+func (o *jsonObject) set(key string, value json.RawMessage) {
+	for i, m := range *o {
+		if m.key == key {
+			(*o)[i].value = value
+			return
+		}
+	}
+
+	*o = append(*o, jsonMember{key: key, value: value})
+}
+
+func (o *jsonObject) setObject(key string, child jsonObject) error {
+	value, err := child.MarshalJSON()
+	if err != nil {
+		return err
+	}
+
+	o.set(key, value)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (o jsonObject) MarshalJSON() ([]byte, error) {
+	buf := bytes.NewBufferString("{")
+	for i, m := range o {
+		if i != 0 {
+			buf.WriteByte(',')
+		}
+
+		key, err := json.Marshal(m.key)
+		if err != nil {
+			return nil, err
+		}
+
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(m.value)
+	}
+
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,134 @@
+package internal_test
+
+import (
+	"encoding/json"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/paluszkiewiczB/validator/internal"
+)
+
+func Test_OpenAPI(t *testing.T) {
+	internal.Log = newTestLog(t)
+
+	src := "package example\n" +
+		"// Customer places the orders.\n" +
+		"type Customer struct {\n" +
+		"	// Name is the full name.\n" +
+		"	Name    string   `json:\"name\" validate:\"required\"`\n" +
+		"	Nick    *string  `json:\"nick\"` // Nick is optional.\n" +
+		"	Address *Address `json:\"address\" validate:\"required\"`\n" +
+		"	Billing *Address `json:\"billing\"`\n" +
+		"}\n" +
+		"type Address struct {\n" +
+		"	City string `json:\"city\" validate:\"required\"`\n" +
+		"}\n"
+
+	// the source is parsed like the input file of the command, the descriptions come from its comments
+	path := filepath.Join(t.TempDir(), "example.go")
+	if err := os.WriteFile(path, []byte(src), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	structs, err := internal.FindStructsInFile(token.NewFileSet(), path)
+	if err != nil {
+		t.Fatalf("finding structs: %v", err)
+	}
+
+	schemas, err := internal.OpenAPISchemas(structs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	existing := `{"openapi": "3.1.0", "info": {"title": "api", "version": "1.2.3"}, "paths": {}, "x-rate": 1.50,
+		"components": {"schemas": {"Other": {"type": "string", "maxLength": 1e2}, "Address": {"type": "integer"}}}}`
+	merged, err := internal.MergeOpenAPI([]byte(existing), "example", schemas)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `{
+	"openapi": "3.1.0",
+	"info": {
+		"title": "api",
+		"version": "1.2.3"
+	},
+	"paths": {},
+	"x-rate": 1.50,
+	"components": {
+		"schemas": {
+			"Other": {
+				"type": "string",
+				"maxLength": 1e2
+			},
+			"Address": {
+				"type": "object",
+				"properties": {
+					"city": {
+						"type": "string",
+						"minLength": 1
+					}
+				},
+				"required": [
+					"city"
+				]
+			},
+			"Customer": {
+				"description": "Customer places the orders.",
+				"type": "object",
+				"properties": {
+					"address": {
+						"$ref": "#/components/schemas/Address"
+					},
+					"billing": {
+						"anyOf": [
+							{
+								"$ref": "#/components/schemas/Address"
+							},
+							{
+								"type": "null"
+							}
+						]
+					},
+					"name": {
+						"description": "Name is the full name.",
+						"type": "string",
+						"minLength": 1
+					},
+					"nick": {
+						"description": "Nick is optional.",
+						"type": [
+							"string",
+							"null"
+						]
+					}
+				},
+				"required": [
+					"name",
+					"address"
+				]
+			}
+		}
+	}
+}
+`
+	if string(merged) != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, merged)
+	}
+
+	created, err := internal.MergeOpenAPI(nil, "example", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var doc map[string]any
+	if err = json.Unmarshal(created, &doc); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if doc["openapi"] != internal.OpenAPIVersion {
+		t.Errorf("expected new document with version %q, got: %s", internal.OpenAPIVersion, created)
+	}
+}
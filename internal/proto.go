@@ -0,0 +1,619 @@
+package internal
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/scanner"
+	"unicode"
+
+	"github.com/emicklei/proto"
+)
+
+// ProtoValidateImport is the import of the protovalidate constraints in the .proto files.
+const ProtoValidateImport = "buf/validate/validate.proto"
+
+// protoFieldOption is the name of the protovalidate field constraints option.
+const protoFieldOption = "(buf.validate.field)"
+
+// protoScalars are the protobuf scalar types of the Go types.
+var protoScalars = map[Type]string{
+	"string":  "string",
+	"bool":    "bool",
+	"int":     "int64",
+	"int64":   "int64",
+	"int8":    "int32",
+	"int16":   "int32",
+	"int32":   "int32",
+	"rune":    "int32",
+	"uint":    "uint64",
+	"uint64":  "uint64",
+	"uintptr": "uint64",
+	"uint8":   "uint32",
+	"uint16":  "uint32",
+	"uint32":  "uint32",
+	"byte":    "uint32",
+	"float32": "float",
+	"float64": "double",
+	"[]byte":  "bytes",
+}
+
+// goScalars are the Go types of the protobuf scalar types, as generated by protoc-gen-go.
+var goScalars = map[string]Type{
+	"string":   "string",
+	"bool":     "bool",
+	"bytes":    "[]byte",
+	"int32":    "int32",
+	"sint32":   "int32",
+	"sfixed32": "int32",
+	"int64":    "int64",
+	"sint64":   "int64",
+	"sfixed64": "int64",
+	"uint32":   "uint32",
+	"fixed32":  "uint32",
+	"uint64":   "uint64",
+	"fixed64":  "uint64",
+	"float":    "float32",
+	"double":   "float64",
+}
+
+// ProtoFile returns the proto3 file in the package pkg with the message of every struct,
+// with the rules translated into the protovalidate field constraints and the option go_package, when it is not empty.
+// Fields are named after the json tag in snake case. Their numbers are never assigned from the order of the struct,
+// which would change the wire format when the fields are reordered, they are read from the protobuf tag instead,
+// e.g. `protobuf:"bytes,3,opt,name=email"` or `protobuf:"3"`, see protoNumber.
+// Fields ignored with `json:"-"`, unexported fields, fields without the number and fields of types without protobuf
+// equivalent are skipped. The rules are checked with the generators first, so the rules rejected by GenerateFile fail
+// the export. Rules without the equivalent, like eqfield, are listed in the WARNING comments of the fields and returned
+// as the warnings.
+func ProtoFile(structs []Struct, pkg, goPackage string) ([]byte, []string, error) {
+	b := &protoBuilder{known: make(map[string]bool, len(structs))}
+	for _, s := range structs {
+		b.known[s.Name] = true
+	}
+
+	out := &bytes.Buffer{}
+	out.WriteString("// Code generated by validator. DO NOT EDIT.\n\n")
+	out.WriteString("syntax = \"proto3\";\n\n")
+	fmt.Fprintf(out, "package %s;\n\n", pkg)
+	if goPackage != "" {
+		fmt.Fprintf(out, "option go_package = %q;\n\n", goPackage)
+	}
+	fmt.Fprintf(out, "import %q;\n", ProtoValidateImport)
+	for _, str := range structs {
+		if err := b.message(out, str); err != nil {
+			return nil, nil, fmt.Errorf("struct: %q, %w", str.Name, err)
+		}
+	}
+
+	return out.Bytes(), b.warnings, nil
+}
+
+type protoBuilder struct {
+	known    map[string]bool
+	warnings []string
+}
+
+func (b *protoBuilder) warn(out *bytes.Buffer, format string, args ...any) {
+	warning := fmt.Sprintf(format, args...)
+	b.warnings = append(b.warnings, warning)
+	fmt.Fprintf(out, "  // WARNING: %s\n", warning)
+}
+
+// message writes the message of the struct, with the fields of all the exported fields of the struct.
+func (b *protoBuilder) message(out *bytes.Buffer, str Struct) error {
+	validated := make(map[string]Field, len(str.Fields))
+	for _, f := range str.Fields {
+		validated[f.Name] = f
+	}
+
+	fmt.Fprintf(out, "\nmessage %s {\n", str.Name)
+	numbers := map[int]string{}
+	for _, astField := range str.Ast.Fields.List {
+		for _, ident := range astField.Names {
+			if !ident.IsExported() {
+				continue
+			}
+
+			name, ok := jsonName(ident.Name, astField)
+			if !ok {
+				continue
+			}
+
+			name = snakeCase(name)
+			field, ok := validated[ident.Name]
+			if !ok {
+				field = Field{Name: ident.Name, Type: Type(types.ExprString(astField.Type)), Ast: astField}
+			}
+
+			typ, ok := b.protoType(field.Type)
+			if !ok {
+				b.warn(out, "%s.%s: type %q has no protobuf equivalent, field skipped", str.Name, name, field.Type)
+				continue
+			}
+
+			number, ok, err := protoNumber(astField)
+			if err != nil {
+				return fmt.Errorf("field: %q, %w", field.Name, err)
+			}
+
+			if !ok {
+				b.warn(out, "%s.%s: no field number in the protobuf tag, field skipped", str.Name, name)
+				continue
+			}
+
+			if other, ok := numbers[number]; ok {
+				return fmt.Errorf("field: %q, field number %d is already used by field %q", field.Name, number, other)
+			}
+			numbers[number] = field.Name
+
+			if err := checkRules(str, field); err != nil {
+				return fmt.Errorf("field: %q, %w", field.Name, err)
+			}
+
+			constraints, skipped := protoConstraints(field.Type, field.Validations)
+			for _, reason := range skipped {
+				b.warn(out, "%s.%s: %s", str.Name, name, reason)
+			}
+
+			options := ""
+			if len(constraints) != 0 {
+				options = " [" + strings.Join(mapSlice(constraints, func(c string) string { return protoFieldOption + "." + c }), ", ") + "]"
+			}
+
+			fmt.Fprintf(out, "  %s %s = %d%s;\n", typ, name, number, options)
+		}
+	}
+
+	out.WriteString("}\n")
+	return nil
+}
+
+// ProtoTagKey is the struct tag key with the field number of the protobuf message, see protoNumber.
+const ProtoTagKey = "protobuf"
+
+// protoNumber returns the field number from the protobuf tag of the field: the second element of the tag of
+// protoc-gen-go, e.g. `protobuf:"bytes,3,opt,name=email,proto3"`, or the only one, e.g. `protobuf:"3"`.
+// It returns false, when the field has no protobuf tag.
+func protoNumber(f *ast.Field) (int, bool, error) {
+	if f.Tag == nil {
+		return 0, false, nil
+	}
+
+	unquoted, err := strconv.Unquote(f.Tag.Value)
+	if err != nil {
+		return 0, false, nil
+	}
+
+	value, ok := reflect.StructTag(unquoted).Lookup(ProtoTagKey)
+	if !ok {
+		return 0, false, nil
+	}
+
+	parts := strings.Split(value, ",")
+	param := parts[0]
+	if len(parts) > 1 {
+		param = parts[1]
+	}
+
+	n, err := strconv.Atoi(param)
+	if err != nil || n < 1 || n > protoMaxNumber || (protoReservedFrom <= n && n <= protoReservedTo) {
+		return 0, false, fmt.Errorf("invalid field number in the protobuf tag: %q", value)
+	}
+
+	return n, true, nil
+}
+
+// field numbers reserved by protobuf and the greatest field number.
+const (
+	protoReservedFrom = 19000
+	protoReservedTo   = 19999
+	protoMaxNumber    = 1<<29 - 1
+)
+
+// protoType returns the type of the field of the Go type, e.g. 'repeated string'.
+func (b *protoBuilder) protoType(t Type) (string, bool) {
+	if scalar, ok := protoScalars[t]; ok {
+		return scalar, true
+	}
+
+	switch {
+	case t.IsPtr():
+		elem, ok := b.protoType(t.Deref())
+		if !ok || strings.HasPrefix(elem, "repeated ") || strings.HasPrefix(elem, "map<") || b.known[string(t.Deref())] {
+			return elem, ok
+		}
+
+		return "optional " + elem, true
+	case t.IsSlice():
+		elem, ok := b.protoType(t.Elem())
+		if !ok || strings.Contains(elem, " ") || strings.HasPrefix(elem, "map<") {
+			return "", false
+		}
+
+		return "repeated " + elem, true
+	case t.IsMap():
+		key, ok := protoScalars[Type(strings.TrimSuffix(strings.TrimPrefix(string(t), "map["), "]"+string(t.Elem())))]
+		if !ok || key == "bytes" || key == "float" || key == "double" {
+			return "", false
+		}
+
+		value, ok := b.protoType(t.Elem())
+		if !ok || strings.Contains(value, " ") || strings.HasPrefix(value, "map<") {
+			return "", false
+		}
+
+		return fmt.Sprintf("map<%s, %s>", key, value), true
+	case b.known[string(t)]:
+		return string(t), true
+	}
+
+	return "", false
+}
+
+// protoConstraints returns the protovalidate constraints of the rules, relative to the field option,
+// e.g. 'string.min_len = 3', and the reasons of skipping the other rules. The rules after Dive are the constraints
+// of the items, e.g. 'repeated.items.string.min_len = 3'.
+func protoConstraints(t Type, vals Validations) ([]string, []string) {
+	vals, elems, dive := vals.Dive()
+	constraints, skipped := protoRules(t, vals, false)
+	if dive && t.IsSlice() {
+		items, itemsSkipped := protoRules(t.Elem(), elems, true)
+		constraints = append(constraints, mapSlice(items, func(c string) string { return "repeated.items." + c })...)
+		skipped = append(skipped, itemsSkipped...)
+	}
+
+	return constraints, skipped
+}
+
+// protoRules returns the constraints of the validations of the field, or of the items, and the reasons of skipping
+// the rules without the equivalent.
+func protoRules(t Type, vals Validations, items bool) ([]string, []string) {
+	var constraints, skipped []string
+	for _, alts := range vals {
+		c, ok := protoConstraint(t, alts[0], items)
+		if len(alts) != 1 || !ok {
+			skipped = append(skipped, fmt.Sprintf("rule %q has no protovalidate equivalent", alts.String()))
+			continue
+		}
+
+		constraints = append(constraints, c...)
+	}
+
+	return constraints, skipped
+}
+
+// protoConstraint returns the constraints of the rule, it returns false if the rule has no equivalent.
+// Required items are the strings with the minimal length, protovalidate ignores required of the items.
+func protoConstraint(t Type, rule Rule, items bool) ([]string, bool) {
+	kind := protoScalars[t]
+	switch rule.Name {
+	case Required:
+		if !items {
+			return []string{"required = true"}, true
+		}
+
+		return []string{"string.min_len = 1"}, t.IsString()
+	case Min, Max, Len:
+		if _, err := strconv.ParseFloat(rule.Param, 64); err != nil {
+			return nil, false
+		}
+
+		switch {
+		case t.IsString():
+			return []string{fmt.Sprintf("string.%s = %s", map[string]string{Min: "min_len", Max: "max_len", Len: "len"}[rule.Name], rule.Param)}, true
+		case t.IsSlice() && rule.Name != Len:
+			return []string{fmt.Sprintf("repeated.%s = %s", map[string]string{Min: "min_items", Max: "max_items"}[rule.Name], rule.Param)}, true
+		case t.IsMap() && rule.Name != Len:
+			return []string{fmt.Sprintf("map.%s = %s", map[string]string{Min: "min_pairs", Max: "max_pairs"}[rule.Name], rule.Param)}, true
+		case t.IsNumber() && rule.Name != Len:
+			return []string{fmt.Sprintf("%s.%s = %s", kind, map[string]string{Min: "gte", Max: "lte"}[rule.Name], rule.Param)}, true
+		}
+	case Oneof:
+		values := oneofValues(rule.Param)
+		if t.IsString() {
+			values = mapSlice(values, strconv.Quote)
+		}
+		return mapSlice(values, func(v string) string { return kind + ".in = " + v }), kind != ""
+	case Regexp:
+		return []string{"string.pattern = " + strconv.Quote(rule.Param)}, true
+	case Email:
+		return []string{"string.email = true"}, true
+	case URL:
+		return []string{"string.uri = true"}, true
+	}
+
+	return nil, false
+}
+
+// ImportProto returns the structs of the messages of the .proto file, with the protovalidate field constraints
+// translated into the rules. Structs and fields are named as generated by protoc-gen-go, fields report
+// the names of the .proto file in the errors. Constraints without the equivalent rule with the generator
+// are skipped and returned as the warnings. Structs have the Ast of all the fields with the json tags of protoc-gen-go,
+// so the other outputs see the same fields, and the AutoReceiver resolves to the pointer receiver,
+// because the messages of protoc-gen-go must not be copied.
+func ImportProto(r io.Reader, filename string) ([]Struct, []string, error) {
+	parser := proto.NewParser(r)
+	parser.Filename(filename)
+	def, err := parser.Parse()
+	if err != nil {
+		return nil, nil, fmt.Errorf("parsing proto: %w", err)
+	}
+
+	messages, enums := map[string]bool{}, map[string]bool{}
+	proto.Walk(def, proto.WithMessage(func(m *proto.Message) {
+		messages[protoMessageName(m)] = true
+	}), proto.WithEnum(func(e *proto.Enum) {
+		name := goCamelCase(e.Name)
+		if parent, ok := e.Parent.(*proto.Message); ok {
+			name = protoMessageName(parent) + "_" + name
+		}
+		enums[name] = true
+	}))
+
+	var structs []Struct
+	var warnings []string
+	proto.Walk(def, proto.WithMessage(func(m *proto.Message) {
+		if m.IsExtend {
+			return
+		}
+
+		str := Struct{Name: protoMessageName(m), Ast: &ast.StructType{Fields: &ast.FieldList{}}, Receiver: Receivers, Unexported: Unexported, Errors: Errors, Templates: Templates}
+		if str.Receiver == AutoReceiver {
+			str.Receiver = PointerReceiver
+		}
+
+		for _, e := range m.Elements {
+			var f *proto.Field
+			var t Type
+			wire := "bytes"
+			switch e := e.(type) {
+			case *proto.NormalField:
+				f, t = e.Field, goType(e.Type, m, messages, enums)
+				wire = wireType(e.Type, enums[string(t)])
+				if e.Optional && !t.IsPtr() && !t.IsSlice() {
+					t = "*" + t
+				}
+				if e.Repeated {
+					t = "[]" + t
+				}
+			case *proto.MapField:
+				f, t = e.Field, Type(fmt.Sprintf("map[%s]%s", goType(e.KeyType, m, messages, enums), goType(e.Type, m, messages, enums)))
+			default:
+				continue
+			}
+
+			astField := &ast.Field{
+				Names: []*ast.Ident{{Name: goCamelCase(f.Name)}},
+				Type:  &ast.Ident{Name: string(t)},
+				Tag:   &ast.BasicLit{Kind: token.STRING, Value: fmt.Sprintf("`protobuf:\"%s,%d,name=%s\" json:\"%s,omitempty\"`", wire, f.Sequence, f.Name, f.Name)},
+			}
+			str.Ast.Fields.List = append(str.Ast.Fields.List, astField)
+
+			vals, skipped := protoValidations(f, t)
+			for _, s := range skipped {
+				warnings = append(warnings, fmt.Sprintf("%s: %s.%s: constraint %q has no supported rule", toPosition(f.Position), m.Name, f.Name, s))
+			}
+
+			if len(vals) != 0 {
+				str.Fields = append(str.Fields, Field{Name: goCamelCase(f.Name), Type: t, Validations: vals, ExternalName: f.Name, Ast: astField})
+			}
+		}
+
+		if len(str.Fields) != 0 {
+			structs = append(structs, str)
+		}
+	}))
+
+	return structs, warnings, nil
+}
+
+// wireType returns the wire type of the protobuf type in the protobuf tag, as protoc-gen-go names it.
+func wireType(t string, enum bool) string {
+	switch t {
+	case "int32", "int64", "uint32", "uint64", "bool":
+		return "varint"
+	case "sint32":
+		return "zigzag32"
+	case "sint64":
+		return "zigzag64"
+	case "fixed32", "sfixed32", "float":
+		return "fixed32"
+	case "fixed64", "sfixed64", "double":
+		return "fixed64"
+	}
+
+	if enum {
+		return "varint"
+	}
+
+	return "bytes"
+}
+
+// protoConstraintValue is the flattened protovalidate constraint, e.g. 'string.min_len' of 3.
+type protoConstraintValue struct {
+	path  string
+	value *proto.Literal
+	pos   scanner.Position
+}
+
+// protoValidations returns the validations of the protovalidate constraints of the field of the Go type t,
+// and the constraints without the equivalent rule.
+func protoValidations(f *proto.Field, t Type) (Validations, []string) {
+	var constraints []protoConstraintValue
+	for _, o := range f.Options {
+		path, ok := strings.CutPrefix(o.Name, protoFieldOption)
+		if !ok {
+			continue
+		}
+
+		constraints = append(constraints, flattenLiteral(strings.TrimPrefix(path, "."), &o.Constant, o.Position)...)
+	}
+
+	var vals Validations
+	var skipped []string
+	for _, c := range constraints {
+		rule, ok := protoRule(c, t)
+		if !ok {
+			skipped = append(skipped, c.path+" = "+c.value.SourceRepresentation())
+			continue
+		}
+
+		vals = append(vals, Alternatives{rule})
+	}
+
+	return vals, skipped
+}
+
+// protoRule returns the rule of the constraint, it returns false if the constraint has no equivalent rule
+// supported by the generator, like the constraints of the elements.
+func protoRule(c protoConstraintValue, t Type) (Rule, bool) {
+	rule := Rule{Param: c.value.Source, Pos: toPosition(c.pos)}
+	kind, constraint, _ := strings.Cut(c.path, ".")
+	switch {
+	case c.path == "required" && rule.Param == "true":
+		rule.Name, rule.Param = Required, ""
+	case kind != "":
+		rule.Name = map[string]string{
+			"min_len": Min, "max_len": Max, "len": Len,
+			"min_items": Min, "max_items": Max,
+			"min_pairs": Min, "max_pairs": Max,
+			"gte": Min, "lte": Max,
+		}[constraint]
+	}
+
+	if rule.Name == "" {
+		return Rule{}, false
+	}
+
+	_, _, err := GenerateValidation(Alternatives{rule}, Struct{Name: "S"}, Field{Name: "F", Type: t})
+	return rule, err == nil
+}
+
+// flattenLiteral returns the constraints of the option value, aggregates like `{string: {min_len: 1}}`
+// are flattened into the paths, e.g. 'string.min_len'. Each value of the array is the separate constraint.
+func flattenLiteral(path string, l *proto.Literal, pos scanner.Position) []protoConstraintValue {
+	if l.Position.Line != 0 {
+		pos = l.Position
+	}
+
+	switch {
+	case len(l.OrderedMap) != 0:
+		var out []protoConstraintValue
+		for _, named := range l.OrderedMap {
+			key := named.Name
+			if path != "" {
+				key = path + "." + key
+			}
+			out = append(out, flattenLiteral(key, named.Literal, pos)...)
+		}
+		return out
+	case len(l.Array) != 0:
+		var out []protoConstraintValue
+		for _, v := range l.Array {
+			out = append(out, flattenLiteral(path, v, pos)...)
+		}
+		return out
+	}
+
+	return []protoConstraintValue{{path: path, value: l, pos: pos}}
+}
+
+// goType returns the Go type of the protobuf type of the field of the message m as generated by protoc-gen-go:
+// messages are pointers. The type is resolved like protoc does, from the scope of m out to the package, e.g. 'Item'
+// in the message Order is 'Order_Item' when Order declares it. Types of the other files are named after the last part.
+func goType(t string, m *proto.Message, messages, enums map[string]bool) Type {
+	if scalar, ok := goScalars[t]; ok {
+		return scalar
+	}
+
+	parts := strings.Split(strings.TrimPrefix(t, "."), ".")
+	for i := range parts {
+		name := strings.Join(mapSlice(parts[i:], goCamelCase), "_")
+		for scope := m; ; {
+			candidate := name
+			if scope != nil {
+				candidate = protoMessageName(scope) + "_" + name
+			}
+
+			if messages[candidate] {
+				return Type("*" + candidate)
+			}
+			if enums[candidate] {
+				return Type(candidate)
+			}
+			if scope == nil {
+				break
+			}
+
+			scope, _ = scope.Parent.(*proto.Message)
+		}
+	}
+
+	return Type("*" + goCamelCase(parts[len(parts)-1]))
+}
+
+// protoMessageName returns the Go name of the message, nested messages are prefixed with the parents.
+func protoMessageName(m *proto.Message) string {
+	name := goCamelCase(m.Name)
+	if parent, ok := m.Parent.(*proto.Message); ok {
+		return protoMessageName(parent) + "_" + name
+	}
+
+	return name
+}
+
+// goCamelCase returns the Go name of the protobuf identifier, as protoc-gen-go does: 'min_len' becomes 'MinLen'.
+func goCamelCase(s string) string {
+	var b []byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '_' && i == 0:
+			b = append(b, 'X')
+		case c == '_' && i+1 < len(s) && 'a' <= s[i+1] && s[i+1] <= 'z':
+		case '0' <= c && c <= '9':
+			b = append(b, c)
+		default:
+			if 'a' <= c && c <= 'z' {
+				c -= 'a' - 'A'
+			}
+			b = append(b, c)
+			for ; i+1 < len(s) && 'a' <= s[i+1] && s[i+1] <= 'z'; i++ {
+				b = append(b, s[i+1])
+			}
+		}
+	}
+
+	return string(b)
+}
+
+// snakeCase returns the name in snake case, e.g. 'orderID' becomes 'order_id'.
+func snakeCase(name string) string {
+	rs := []rune(name)
+	sb := strings.Builder{}
+	for i, r := range rs {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			sb.WriteRune('_')
+			continue
+		}
+
+		upper := unicode.IsUpper(r)
+		if upper && i > 0 && (unicode.IsLower(rs[i-1]) || unicode.IsDigit(rs[i-1]) || (i+1 < len(rs) && unicode.IsLower(rs[i+1]) && unicode.IsUpper(rs[i-1]))) {
+			sb.WriteRune('_')
+		}
+		sb.WriteRune(unicode.ToLower(r))
+	}
+
+	return sb.String()
+}
+
+func toPosition(p scanner.Position) token.Position {
+	return token.Position{Filename: p.Filename, Offset: p.Offset, Line: p.Line, Column: p.Column}
+}
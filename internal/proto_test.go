@@ -0,0 +1,238 @@
+package internal_test
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/paluszkiewiczB/validator/internal"
+)
+
+func Test_ProtoFile(t *testing.T) {
+	internal.Log = newTestLog(t)
+
+	src := "package example\n" +
+		"type Order struct {\n" +
+		"	ID       string            `json:\"id\" protobuf:\"bytes,1,opt,name=id,proto3\" validate:\"required,len=26\"`\n" +
+		"	Status   string            `json:\"status,omitempty\" protobuf:\"2\" validate:\"oneof=new paid\"`\n" +
+		"	Email    *string           `json:\"email\" protobuf:\"3\" validate:\"required\"`\n" +
+		"	Contact  string            `json:\"contact\" protobuf:\"12\" validate:\"email|url\"`\n" +
+		"	Code     string            `protobuf:\"4\" validate:\"len=0|min=2,max=8,regexp=^[A-Z]+$\"`\n" +
+		"	Quantity int               `json:\"quantity\" protobuf:\"5\" validate:\"min=1,gte=Min,oneof=1 10\"`\n" +
+		"	Tags     []string          `json:\"tags\" protobuf:\"6\" validate:\"max=5,dive,required,url\"`\n" +
+		"	Items    []*Item           `json:\"items\" protobuf:\"7\" validate:\"required\"`\n" +
+		"	Labels   map[string]string `json:\"labels\" protobuf:\"8\"`\n" +
+		"	OrderID  *int32            `protobuf:\"9\"`\n" +
+		"	Note     string\n" +
+		"	Channel  chan int          `protobuf:\"10\"`\n" +
+		"	Min      int               `json:\"-\"`\n" +
+		"}\n" +
+		"type Item struct {\n" +
+		"	Price float64 `json:\"price\" protobuf:\"fixed64,1,opt,name=price,proto3\" validate:\"min=1.5\"`\n" +
+		"}\n"
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "example.go", src, 0)
+	if err != nil {
+		t.Fatalf("parsing source: %v", err)
+	}
+
+	structs, err := internal.FindStructs(fset, f)
+	if err != nil {
+		t.Fatalf("finding structs: %v", err)
+	}
+
+	content, warnings, err := internal.ProtoFile(structs, "example", "example.com/shop/example")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `// Code generated by validator. DO NOT EDIT.
+
+syntax = "proto3";
+
+package example;
+
+option go_package = "example.com/shop/example";
+
+import "buf/validate/validate.proto";
+
+message Item {
+  double price = 1 [(buf.validate.field).double.gte = 1.5];
+}
+
+message Order {
+  string id = 1 [(buf.validate.field).required = true, (buf.validate.field).string.len = 26];
+  string status = 2 [(buf.validate.field).string.in = "new", (buf.validate.field).string.in = "paid"];
+  optional string email = 3 [(buf.validate.field).required = true];
+  // WARNING: Order.contact: rule "email|url" has no protovalidate equivalent
+  string contact = 12;
+  // WARNING: Order.code: rule "len=0|min=2" has no protovalidate equivalent
+  string code = 4 [(buf.validate.field).string.max_len = 8, (buf.validate.field).string.pattern = "^[A-Z]+$"];
+  // WARNING: Order.quantity: rule "gte=Min" has no protovalidate equivalent
+  int64 quantity = 5 [(buf.validate.field).int64.gte = 1, (buf.validate.field).int64.in = 1, (buf.validate.field).int64.in = 10];
+  repeated string tags = 6 [(buf.validate.field).repeated.max_items = 5, (buf.validate.field).repeated.items.string.min_len = 1, (buf.validate.field).repeated.items.string.uri = true];
+  repeated Item items = 7 [(buf.validate.field).required = true];
+  map<string, string> labels = 8;
+  optional int32 order_id = 9;
+  // WARNING: Order.note: no field number in the protobuf tag, field skipped
+  // WARNING: Order.channel: type "chan int" has no protobuf equivalent, field skipped
+}
+`
+	if string(content) != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, content)
+	}
+
+	if len(warnings) != 5 {
+		t.Errorf("expected 5 warnings, got: %q", warnings)
+	}
+}
+
+func Test_ProtoFile_FieldNumbers(t *testing.T) {
+	internal.Log = newTestLog(t)
+
+	cases := map[string]struct {
+		fields string
+		err    string
+	}{
+		"reordered": {
+			fields: "	B string `protobuf:\"bytes,2,opt,name=b\" validate:\"required\"`\n	A string `protobuf:\"bytes,1,opt,name=a\" validate:\"required\"`\n",
+		},
+		"duplicate": {
+			fields: "	A string `protobuf:\"1\" validate:\"required\"`\n	B string `protobuf:\"1\" validate:\"required\"`\n",
+			err:    `field: "B", field number 1 is already used by field "A"`,
+		},
+		"reserved": {
+			fields: "	A string `protobuf:\"bytes,19000,opt\" validate:\"required\"`\n",
+			err:    `field: "A", invalid field number in the protobuf tag: "bytes,19000,opt"`,
+		},
+		"not a number": {
+			fields: "	A string `protobuf:\"bytes,opt\" validate:\"required\"`\n",
+			err:    `field: "A", invalid field number in the protobuf tag: "bytes,opt"`,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			fset := token.NewFileSet()
+			f, err := parser.ParseFile(fset, "example.go", "package example\ntype User struct {\n"+c.fields+"}\n", 0)
+			if err != nil {
+				t.Fatalf("parsing source: %v", err)
+			}
+
+			structs, err := internal.FindStructs(fset, f)
+			if err != nil {
+				t.Fatalf("finding structs: %v", err)
+			}
+
+			content, _, err := internal.ProtoFile(structs, "example", "")
+			if c.err == "" {
+				if err != nil || !strings.Contains(string(content), "  string b = 2 [(buf.validate.field).required = true];\n  string a = 1 [(buf.validate.field).required = true];\n") {
+					t.Errorf("expected the numbers of the tags, got: %v\n%s", err, content)
+				}
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), c.err) {
+				t.Errorf("expected error %q, got: %v", c.err, err)
+			}
+		})
+	}
+}
+
+func Test_ImportProto(t *testing.T) {
+	internal.Log = newTestLog(t)
+
+	src := `syntax = "proto3";
+
+package example;
+
+import "buf/validate/validate.proto";
+
+message Order {
+  string order_id = 1 [(buf.validate.field).required = true, (buf.validate.field).string.len = 26];
+  string status = 2 [(buf.validate.field).string = {in: ["new", "paid"], min_len: 3}];
+  int64 quantity = 3 [(buf.validate.field).int64.gte = 1, (buf.validate.field).int64.lte = 100];
+  repeated string tags = 4 [(buf.validate.field).repeated.max_items = 5, (buf.validate.field).repeated.items.string.uuid = true];
+  Item item = 5 [(buf.validate.field).required = true];
+  map<string, string> labels = 6 [(buf.validate.field).map.min_pairs = 1];
+  string note = 7;
+
+  message Item {
+    double price = 1 [(buf.validate.field).double.gte = 0.5];
+  }
+}
+`
+
+	structs, warnings, err := internal.ImportProto(strings.NewReader(src), "example.proto")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string]map[string]string{
+		"Order": {
+			"OrderId":  "required,len=26",
+			"Status":   "min=3",
+			"Quantity": "min=1,max=100",
+			"Tags":     "max=5",
+			"Item":     "required",
+			"Labels":   "min=1",
+		},
+		"Order_Item": {
+			"Price": "min=0.5",
+		},
+	}
+
+	if len(structs) != len(expected) {
+		t.Fatalf("expected structs: %v, got: %v", expected, structs)
+	}
+
+	for _, str := range structs {
+		fields, ok := expected[str.Name]
+		if !ok || len(fields) != len(str.Fields) {
+			t.Fatalf("unexpected struct: %v", str)
+		}
+
+		for _, field := range str.Fields {
+			if got := field.Validations.String(); got != fields[field.Name] {
+				t.Errorf("struct: %q, field: %q, expected: %q, got: %q", str.Name, field.Name, fields[field.Name], got)
+			}
+		}
+	}
+
+	expectedWarnings := []string{
+		`example.proto:9:3: Order.status: constraint "string.in = \"new\"" has no supported rule`,
+		`example.proto:9:3: Order.status: constraint "string.in = \"paid\"" has no supported rule`,
+		`example.proto:11:12: Order.tags: constraint "repeated.items.string.uuid = true" has no supported rule`,
+	}
+	if strings.Join(warnings, "\n") != strings.Join(expectedWarnings, "\n") {
+		t.Errorf("expected warnings:\n%s\ngot:\n%s", strings.Join(expectedWarnings, "\n"), strings.Join(warnings, "\n"))
+	}
+
+	out, err := internal.GenerateFile(structs, "example")
+	if err != nil {
+		t.Fatalf("generating file: %v", err)
+	}
+
+	if !strings.Contains(string(out), "func (o *Order) Validate() error") || !strings.Contains(string(out), `Field: "order_id"`) {
+		t.Errorf("expected validation of Order with the pointer receiver reporting proto names, got:\n%s", out)
+	}
+
+	content, _, err := internal.ProtoFile(structs, "example", "")
+	if err != nil {
+		t.Fatalf("exporting imported structs: %v", err)
+	}
+
+	if !strings.Contains(string(content), "string note = 7;") {
+		t.Errorf("expected all the fields of the imported message, got:\n%s", content)
+	}
+
+	if _, err := internal.JSONSchemas(structs); err != nil {
+		t.Errorf("exporting imported structs to JSON Schema: %v", err)
+	}
+
+	if _, _, err := internal.ZodSchemas(structs); err != nil {
+		t.Errorf("exporting imported structs to Zod: %v", err)
+	}
+}
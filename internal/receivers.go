@@ -0,0 +1,146 @@
+package internal
+
+import (
+	"fmt"
+	"go/ast"
+	"strings"
+)
+
+// ReceiverKind is the kind of the receiver of the generated methods.
+type ReceiverKind string
+
+const (
+	// AutoReceiver is the pointer receiver, when any method of the type declared in the package has the pointer
+	// receiver or the type has the modifiers, and the value receiver otherwise.
+	AutoReceiver ReceiverKind = "auto"
+	// PointerReceiver is the receiver '(r *T)', which does not copy the struct.
+	PointerReceiver ReceiverKind = "pointer"
+	// ValueReceiver is the receiver '(r T)'.
+	ValueReceiver ReceiverKind = "value"
+)
+
+// Receivers is the ReceiverKind of the methods of the structs without the ReceiverDirective.
+var Receivers = AutoReceiver
+
+// Unexported generates the methods validate and validateGroup instead of Validate and ValidateGroup for all the structs,
+// so the package can wrap them in its own exported methods, see UnexportedDirective.
+var Unexported = false
+
+// Directives of the doc comment of the struct, overriding Receivers and Unexported:
+//
+//	//validator:receiver pointer
+//	//validator:unexported
+//	type User struct{}
+const (
+	ReceiverDirective   = "//validator:receiver "
+	UnexportedDirective = "//validator:unexported"
+)
+
+// ParseReceiverKind parses the ReceiverKind, e.g. of the flag.
+func ParseReceiverKind(kind string) (ReceiverKind, error) {
+	switch k := ReceiverKind(kind); k {
+	case AutoReceiver, PointerReceiver, ValueReceiver:
+		return k, nil
+	}
+
+	return "", fmt.Errorf("unknown receiver %q, expected %q, %q or %q", kind, AutoReceiver, PointerReceiver, ValueReceiver)
+}
+
+// typeDirectives sets the Receiver and Unexported of the struct from the settings and the directives of its doc,
+// and the Errors and Templates from the settings.
+func typeDirectives(str Struct, settings Settings) (Struct, error) {
+	str.Receiver, str.Unexported = settings.Receivers, settings.Unexported
+	str.Errors, str.Templates = settings.Errors, settings.Templates
+	if str.Doc == nil {
+		return str, nil
+	}
+
+	for _, c := range str.Doc.List {
+		if c.Text == UnexportedDirective {
+			str.Unexported = true
+			continue
+		}
+
+		kind, ok := strings.CutPrefix(c.Text, ReceiverDirective)
+		if !ok {
+			continue
+		}
+
+		receiver, err := ParseReceiverKind(strings.TrimSpace(kind))
+		if err != nil {
+			return Struct{}, fmt.Errorf("struct: %q, %w", str.Name, err)
+		}
+
+		str.Receiver = receiver
+	}
+
+	return str, nil
+}
+
+// DetectReceivers resolves the AutoReceiver of the structs with the receivers of the methods declared in the files,
+// e.g. of the package. Generated files are skipped, so the previous output of the generator does not decide.
+// Structs with the modifiers use the pointer receiver, like their generated method Normalize.
+func DetectReceivers(structs []Struct, files ...*ast.File) []Struct {
+	pointers := map[string]bool{}
+	for _, f := range files {
+		if ast.IsGenerated(f) {
+			continue
+		}
+
+		for _, decl := range f.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || len(fn.Recv.List) == 0 {
+				continue
+			}
+
+			if star, ok := fn.Recv.List[0].Type.(*ast.StarExpr); ok {
+				pointers[receiverType(star.X)] = true
+			}
+		}
+	}
+
+	out := make([]Struct, len(structs))
+	for i, str := range structs {
+		if str.Receiver == AutoReceiver && (pointers[str.Name] || str.HasModifiers()) {
+			str.Receiver = PointerReceiver
+		}
+
+		out[i] = str
+	}
+
+	return out
+}
+
+// receiverType returns the name of the type of the receiver, without the type parameters.
+func receiverType(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return e.Name
+	case *ast.IndexExpr:
+		return receiverType(e.X)
+	case *ast.IndexListExpr:
+		return receiverType(e.X)
+	case *ast.ParenExpr:
+		return receiverType(e.X)
+	}
+
+	return ""
+}
+
+// ValidateMethod returns the name of the generated method validating the struct.
+func ValidateMethod(str Struct) string {
+	if str.Unexported {
+		return "validate"
+	}
+
+	return "Validate"
+}
+
+// ValidateGroupMethod returns the name of the generated method validating the struct in the group.
+func ValidateGroupMethod(str Struct) string {
+	if str.Unexported {
+		return "validateGroup"
+	}
+
+	return "ValidateGroup"
+}
@@ -0,0 +1,126 @@
+package internal_test
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/paluszkiewiczB/validator/internal"
+)
+
+const receiverSource = `package example
+
+type User struct {
+	Name string ` + "`" + `validate:"required"` + "`" + `
+}
+
+func (u *User) Rename(name string) {
+	u.Name = name
+}
+
+type Page[T any] struct {
+	Items []T ` + "`" + `validate:"max=10"` + "`" + `
+}
+
+func (p *Page[T]) Append(item T) {
+	p.Items = append(p.Items, item)
+}
+
+//validator:receiver value
+type Point struct {
+	X int ` + "`" + `validate:"min=0"` + "`" + `
+}
+
+func (p *Point) Move(x int) {
+	p.X = x
+}
+
+//validator:unexported
+type Secret struct {
+	Value string ` + "`" + `validate:"required" validate.create:"required"` + "`" + `
+}
+`
+
+func Test_GenerateFile_Receivers(t *testing.T) {
+	internal.Log = newTestLog(t)
+
+	out := generate(t, receiverSource)
+	for _, expected := range []string{
+		"func (u *User) Validate() error {",
+		"func (p *Page[T]) Validate() error {",
+		"func (p Point) Validate() error {",
+		"// validate validates the struct, the package can wrap it in its own Validate.\nfunc (s Secret) validate() error {",
+		"func (s Secret) validateGroup(group string) error {",
+		"return s.validate()",
+	} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("expected %q in:\n%s", expected, out)
+		}
+	}
+}
+
+func Test_GenerateFile_Receivers_Globals(t *testing.T) {
+	internal.Log = newTestLog(t)
+	internal.Receivers, internal.Unexported = internal.PointerReceiver, true
+	t.Cleanup(func() { internal.Receivers, internal.Unexported = internal.AutoReceiver, false })
+
+	out := generate(t, receiverSource)
+	for _, expected := range []string{
+		"func (u *User) validate() error {",
+		"func (p Point) validate() error {",
+		"func (s *Secret) validate() error {",
+	} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("expected %q in:\n%s", expected, out)
+		}
+	}
+}
+
+func Test_DetectReceivers(t *testing.T) {
+	internal.Log = newTestLog(t)
+
+	fset := token.NewFileSet()
+	src, err := parser.ParseFile(fset, "example.go", "package example\n\ntype User struct {\n\tName string `validate:\"required\"`\n}\n", parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	methods, err := parser.ParseFile(fset, "methods.go", "package example\n\nfunc (u *User) Rename(name string) {}\n", parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	generated, err := parser.ParseFile(fset, "generated.go", "// Code generated by validator. DO NOT EDIT.\n\npackage example\n\nfunc (u *User) Validate() error { return nil }\n", parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	structs, err := internal.FindStructs(fset, src)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := internal.DetectReceivers(structs, generated)[0].Receiver; got != internal.AutoReceiver {
+		t.Errorf("expected the methods of the generated file to be skipped, got receiver: %q", got)
+	}
+
+	if got := internal.DetectReceivers(structs, methods)[0].Receiver; got != internal.PointerReceiver {
+		t.Errorf("expected pointer receiver, got: %q", got)
+	}
+}
+
+func Test_FindStructs_ReceiverDirective(t *testing.T) {
+	internal.Log = newTestLog(t)
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "example.go", "package example\n\n//validator:receiver reference\ntype User struct {\n\tName string `validate:\"required\"`\n}\n", parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = internal.FindStructs(fset, f)
+	if err == nil || !strings.Contains(err.Error(), `unknown receiver "reference"`) {
+		t.Errorf("expected unknown receiver error, got: %v", err)
+	}
+}
@@ -0,0 +1,234 @@
+package internal
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// DBTagKey is the struct tag key of the column names, e.g. `db:"name"`.
+const DBTagKey = "db"
+
+// SQLMigration returns the PostgreSQL migration with the CHECK constraints of the rules of every struct
+// with the db tags. The table is the struct name in snake case and the columns are the names of the db tags,
+// fields without the db tag or with `db:"-"` are skipped. Each column gets the single constraint named
+// '<table>_<column>_check', which is dropped first, so the migration can be applied again after the rules change.
+// The rules are checked with the generators first, so the rules rejected by GenerateFile fail the export.
+// Rules without the SQL equivalent, like eqfield of the field without the column or the rules of the elements,
+// are listed in the comments and returned as the warnings.
+func SQLMigration(structs []Struct) ([]byte, []string, error) {
+	out := &bytes.Buffer{}
+	out.WriteString("-- Code generated by validator. DO NOT EDIT.\n")
+	var warnings []string
+	for _, str := range structs {
+		columns := dbColumns(str)
+		if len(columns) == 0 {
+			continue
+		}
+
+		table := snakeCase(str.Name)
+		fmt.Fprintf(out, "\n-- %s\n", str.Name)
+		for _, field := range str.Fields {
+			column, ok := columns[field.Name]
+			if !ok {
+				continue
+			}
+
+			if err := checkRules(str, field); err != nil {
+				return nil, nil, fmt.Errorf("struct: %q, field: %q, %w", str.Name, field.Name, err)
+			}
+
+			conds, skipped := sqlConditions(field, columns)
+			if len(skipped) != 0 {
+				warning := fmt.Sprintf("%s.%s: rules without SQL equivalent: %s", table, column, strings.Join(skipped, ", "))
+				warnings = append(warnings, warning)
+				fmt.Fprintf(out, "-- %s\n", warning)
+			}
+
+			if len(conds) == 0 {
+				continue
+			}
+
+			name := quoteIdent(table + "_" + column + "_check")
+			fmt.Fprintf(out, "ALTER TABLE %s DROP CONSTRAINT IF EXISTS %s;\n", quoteIdent(table), name)
+			fmt.Fprintf(out, "ALTER TABLE %s ADD CONSTRAINT %s CHECK (%s);\n", quoteIdent(table), name, strings.Join(conds, " AND "))
+		}
+	}
+
+	return out.Bytes(), warnings, nil
+}
+
+// dbColumns returns the column names of the fields with the db tag, keyed by the field name.
+func dbColumns(str Struct) map[string]string {
+	columns := map[string]string{}
+	if str.Ast == nil {
+		return columns
+	}
+
+	for _, f := range str.Ast.Fields.List {
+		column, ok := dbName(f)
+		if !ok {
+			continue
+		}
+
+		for _, ident := range f.Names {
+			columns[ident.Name] = column
+		}
+	}
+
+	return columns
+}
+
+// dbName returns the column name of the db tag of the field and false, when the field has none or is ignored.
+func dbName(f *ast.Field) (string, bool) {
+	if f.Tag == nil {
+		return "", false
+	}
+
+	unquoted, err := strconv.Unquote(f.Tag.Value)
+	if err != nil {
+		return "", false
+	}
+
+	value, ok := reflect.StructTag(unquoted).Lookup(DBTagKey)
+	column, _, _ := strings.Cut(value, ",")
+	if !ok || column == "" || column == "-" {
+		return "", false
+	}
+
+	return column, true
+}
+
+// sqlConditions returns the conditions of the rules of the field and the rules without the SQL equivalent, which
+// include the rules of the elements after Dive. Lower and upper bounds of the same expression are merged into BETWEEN.
+func sqlConditions(field Field, columns map[string]string) ([]string, []string) {
+	var conds []sqlCond
+	var skipped []string
+	vals, elems, dive := field.Validations.Dive()
+	for _, alts := range vals {
+		alt := make([]string, 0, len(alts))
+		for _, rule := range alts {
+			c, ok := sqlCondition(field, rule, columns)
+			if !ok {
+				alt = nil
+				break
+			}
+			alt = append(alt, c.String())
+			if len(alts) == 1 {
+				conds = append(conds, c)
+			}
+		}
+
+		switch {
+		case len(alt) == 0:
+			skipped = append(skipped, alts.String())
+		case len(alts) > 1:
+			conds = append(conds, sqlCond{lhs: "(" + strings.Join(alt, " OR ") + ")"})
+		}
+	}
+
+	if dive {
+		skipped = append(skipped, append(Validations{{{Name: Dive}}}, elems...).String())
+	}
+
+	return mergeBetween(conds), skipped
+}
+
+// sqlCond is the condition 'lhs op rhs', or the whole condition in lhs when op is empty.
+type sqlCond struct {
+	lhs, op, rhs string
+}
+
+func (c sqlCond) String() string {
+	if c.op == "" {
+		return c.lhs
+	}
+
+	return c.lhs + " " + c.op + " " + c.rhs
+}
+
+// mergeBetween merges the lower and upper bounds of the same expression into BETWEEN, at the position of the lower bound.
+func mergeBetween(conds []sqlCond) []string {
+	var out []string
+	merged := map[int]bool{}
+	for i, c := range conds {
+		if merged[i] {
+			continue
+		}
+
+		if c.op == ">=" {
+			for j := i + 1; j < len(conds); j++ {
+				if !merged[j] && conds[j].op == "<=" && conds[j].lhs == c.lhs {
+					merged[j] = true
+					c = sqlCond{lhs: fmt.Sprintf("%s BETWEEN %s AND %s", c.lhs, c.rhs, conds[j].rhs)}
+					break
+				}
+			}
+		}
+
+		out = append(out, c.String())
+	}
+
+	return out
+}
+
+// sqlCondition returns the condition of the rule, it returns false if the rule has no SQL equivalent.
+func sqlCondition(field Field, rule Rule, columns map[string]string) (sqlCond, bool) {
+	column := quoteIdent(columns[field.Name])
+	t := field.Type.Deref()
+	size := column
+	switch {
+	case t.IsString():
+		size = "char_length(" + column + ")"
+	case t.IsSlice():
+		size = "cardinality(" + column + ")"
+	}
+
+	switch rule.Name {
+	case Required:
+		switch {
+		case field.Type.IsPtr():
+			return sqlCond{lhs: column, op: "IS", rhs: "NOT NULL"}, true
+		case t.IsString():
+			return sqlCond{lhs: column, op: "<>", rhs: "''"}, true
+		case t.IsSlice():
+			return sqlCond{lhs: size, op: ">", rhs: "0"}, true
+		}
+	case Min, Max, Len:
+		if _, err := strconv.ParseFloat(rule.Param, 64); err != nil || !(t.IsString() || t.IsSlice() || t.IsNumber()) {
+			return sqlCond{}, false
+		}
+
+		return sqlCond{lhs: size, op: map[string]string{Min: ">=", Max: "<=", Len: "="}[rule.Name], rhs: rule.Param}, true
+	case Eqfield, Gte:
+		other, ok := columns[rule.Param]
+		if !ok {
+			return sqlCond{}, false
+		}
+
+		return sqlCond{lhs: column, op: map[string]string{Eqfield: "=", Gte: ">="}[rule.Name], rhs: quoteIdent(other)}, true
+	case Oneof:
+		values := oneofValues(rule.Param)
+		if t.IsString() {
+			values = mapSlice(values, quoteLiteral)
+		}
+
+		return sqlCond{lhs: column, op: "IN", rhs: "(" + strings.Join(values, ", ") + ")"}, true
+	case Regexp:
+		return sqlCond{lhs: column, op: "~", rhs: quoteLiteral(rule.Param)}, true
+	}
+
+	return sqlCond{}, false
+}
+
+func quoteIdent(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// quoteLiteral returns the SQL string literal, with the standard conforming strings backslashes are not escapes.
+func quoteLiteral(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
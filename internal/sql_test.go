@@ -0,0 +1,99 @@
+package internal_test
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/paluszkiewiczB/validator/internal"
+)
+
+func Test_SQLMigration(t *testing.T) {
+	internal.Log = newTestLog(t)
+
+	src := "package example\n" +
+		"type UserAccount struct {\n" +
+		"	Name     string   `db:\"name\" validate:\"required,min=3,max=64\"`\n" +
+		"	Status   string   `db:\"status\" validate:\"oneof=new active o'clock\"`\n" +
+		"	Email    *string  `db:\"email\" validate:\"required\"`\n" +
+		"	Contact  string   `db:\"contact\" validate:\"email\"`\n" +
+		"	Level    int      `db:\"level\" validate:\"oneof=1 2 3\"`\n" +
+		"	Age      int      `db:\"age\" validate:\"min=18|eqfield=Limit\"`\n" +
+		"	Limit    int      `db:\"age_limit\" validate:\"gte=Age\"`\n" +
+		"	Code     string   `db:\"code\" validate:\"regexp=^[A-Z]+$,len=4\"`\n" +
+		"	Tags     []string `db:\"tags\" validate:\"max=5,dive,max=10\"`\n" +
+		"	Nick     string   `db:\"nick\" validate:\"eqfield=Repeated\"`\n" +
+		"	Password string   `db:\"-\" validate:\"required\"`\n" +
+		"	Repeated string   `validate:\"eqfield=Password\"`\n" +
+		"}\n" +
+		"type Request struct {\n" +
+		"	Name string `validate:\"required\"`\n" +
+		"}\n"
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "example.go", src, 0)
+	if err != nil {
+		t.Fatalf("parsing source: %v", err)
+	}
+
+	structs, err := internal.FindStructs(fset, f)
+	if err != nil {
+		t.Fatalf("finding structs: %v", err)
+	}
+
+	content, warnings, err := internal.SQLMigration(structs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `-- Code generated by validator. DO NOT EDIT.
+
+-- UserAccount
+ALTER TABLE "user_account" DROP CONSTRAINT IF EXISTS "user_account_name_check";
+ALTER TABLE "user_account" ADD CONSTRAINT "user_account_name_check" CHECK ("name" <> '' AND char_length("name") BETWEEN 3 AND 64);
+ALTER TABLE "user_account" DROP CONSTRAINT IF EXISTS "user_account_status_check";
+ALTER TABLE "user_account" ADD CONSTRAINT "user_account_status_check" CHECK ("status" IN ('new', 'active', 'o''clock'));
+ALTER TABLE "user_account" DROP CONSTRAINT IF EXISTS "user_account_email_check";
+ALTER TABLE "user_account" ADD CONSTRAINT "user_account_email_check" CHECK ("email" IS NOT NULL);
+-- user_account.contact: rules without SQL equivalent: email
+ALTER TABLE "user_account" DROP CONSTRAINT IF EXISTS "user_account_level_check";
+ALTER TABLE "user_account" ADD CONSTRAINT "user_account_level_check" CHECK ("level" IN (1, 2, 3));
+ALTER TABLE "user_account" DROP CONSTRAINT IF EXISTS "user_account_age_check";
+ALTER TABLE "user_account" ADD CONSTRAINT "user_account_age_check" CHECK (("age" >= 18 OR "age" = "age_limit"));
+ALTER TABLE "user_account" DROP CONSTRAINT IF EXISTS "user_account_age_limit_check";
+ALTER TABLE "user_account" ADD CONSTRAINT "user_account_age_limit_check" CHECK ("age_limit" >= "age");
+ALTER TABLE "user_account" DROP CONSTRAINT IF EXISTS "user_account_code_check";
+ALTER TABLE "user_account" ADD CONSTRAINT "user_account_code_check" CHECK ("code" ~ '^[A-Z]+$' AND char_length("code") = 4);
+-- user_account.tags: rules without SQL equivalent: dive,max=10
+ALTER TABLE "user_account" DROP CONSTRAINT IF EXISTS "user_account_tags_check";
+ALTER TABLE "user_account" ADD CONSTRAINT "user_account_tags_check" CHECK (cardinality("tags") <= 5);
+-- user_account.nick: rules without SQL equivalent: eqfield=Repeated
+`
+	if string(content) != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, content)
+	}
+
+	if len(warnings) != 3 {
+		t.Errorf("expected 3 warnings, got: %q", warnings)
+	}
+}
+
+func Test_SQLMigration_RejectedRule(t *testing.T) {
+	internal.Log = newTestLog(t)
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "example.go", "package example\ntype User struct {\n\tLevel float64 `db:\"level\" validate:\"oneof=1.5 2\"`\n}\n", 0)
+	if err != nil {
+		t.Fatalf("parsing source: %v", err)
+	}
+
+	structs, err := internal.FindStructs(fset, f)
+	if err != nil {
+		t.Fatalf("finding structs: %v", err)
+	}
+
+	_, _, err = internal.SQLMigration(structs)
+	if err == nil || !strings.Contains(err.Error(), `unsupported type for validation: "oneof", type: "float64"`) {
+		t.Errorf("expected error of the rule rejected by the generator, got: %v", err)
+	}
+}
@@ -1,257 +1,503 @@
 package internal
 
-import "fmt"
-
-type where int
+import (
+	"fmt"
+	"go/token"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
 
 // For given example: `validate:"required,oneof=red green blue,oneof=r g b"`.
-// The entire expression is surrounded by backquotes ```.
-// The first and last rune must be backquote and are described by begin and end.
-// tagKey is the word `validate`
-// tagSeparator is the colon `:< between `validate` and `"required“
-// tagValue is the quoted value `"required,oneof=red green blue,oneof=r g b"` without end raw quote.
-// The tag value is internally composed of either boolean attributes or key-value pairs.
-// pairKey is the key of the key-value pair OR a boolean attribute, so both `oneof` and `required`.
-// pairKeyValueSeparator is the equal sign `=` between pairKey and the pairValue.
-// pairValue is the value of the key-value pair, so `red green blue` or `r g b`.
-// pairSeparator is the comma `,` between two pairs.
-// insideTagValueQuotes is the value without quotes `required,oneof=red green blue,oneof=r g b`
+// The entire expression is a Go string literal, either raw (surrounded by backquotes) or interpreted.
+// Unquoted, it follows the grammar of reflect.StructTag: space separated pairs of tagKey, colon and tagValue.
+// tagKey is one of TagKeys, e.g. `validate`, optionally qualified with the group: `validate.create`.
+// tagValue is the quoted value `"required,oneof=red green blue,oneof=r g b"`, which is a Go string literal itself.
+// The tag value is internally composed of rules separated by the comma `,`.
+// Rule is either a boolean attribute `required` or a key-value pair `oneof=red green blue` separated by the equal sign `=`.
+// Rules can be combined with the pipe `|` into alternatives: `rgb|rgba|hexcolor`.
+// Rules can be prefixed with the group and the colon: `create:required`, the group lasts until the semicolon `;`.
+// Commas and pipes in the value of the pair must be escaped as in go-playground/validator: `0x2C` and `0x7C`.
+// Colons and semicolons are the part of the value, e.g. `regexp=^a:b$` or `oneof=a;b`, except for the semicolon
+// in the value of the rule of the group, or followed by the group prefix, which must be escaped as `0x3B`.
+
+// DefaultTagKey is the struct tag key of the rules of go-playground/validator.
+const DefaultTagKey = "validate"
+
+// TagKeys are the struct tag keys of the rules in the priority order, e.g. 'binding' of gin and 'validate'.
+// Rules of all the keys of the field are merged: the rules of the key with the higher priority come first and the
+// rule of the key with the lower priority is dropped, when the key with the higher priority has the rule of the same name.
+var TagKeys = []string{DefaultTagKey}
+
+// Aliases are the names of the custom rules expanded into the rules of the value at parse time, like RegisterAlias of
+// go-playground/validator, e.g. 'iscolor' into 'hexcolor|rgb|rgba'. The value follows the grammar of the tag value,
+// without the groups. Alias with multiple rules, e.g. 'required,max=10', can not be one of the alternatives.
+var Aliases = map[string]string{}
+
+// Syntax are the settings of parsing the rules of the tags.
+type Syntax struct {
+	// TagKeys are the struct tag keys of the rules in the priority order, see TagKeys.
+	TagKeys []string
+	// Aliases are the custom rules, see Aliases.
+	Aliases map[string]string
+}
+
+// CurrentSyntax returns the Syntax of TagKeys and Aliases.
+func CurrentSyntax() Syntax {
+	return Syntax{TagKeys: TagKeys, Aliases: Aliases}
+}
+
+// Escapes of the runes in the value of the pair.
 const (
-	idk where = 1 << iota
-	begin
-	tagKey
-	tagSeparator
-	tagValue
-	pairKey
-	pairKeyValueSeparator
-	pairValue
-	pairSeparator
-	end
+	escapedComma     = "0x2C"
+	escapedPipe      = "0x7C"
+	escapedSemicolon = "0x3B"
 )
 
-type parseState struct {
-	rawQuotes uint8
-	at, next  where
+// TagError is the error of parsing the struct tag, at the position of the offending rune.
+type TagError struct {
+	Pos token.Position
+	Msg string
+}
+
+// Error implements error.
+func (e *TagError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Pos, e.Msg)
+}
+
+// tagRune is the rune of the unquoted literal with its position in the source.
+type tagRune struct {
+	r   rune
+	pos token.Position
+}
 
-	// skipTag is true when the tagKey is not 'validate'
-	// value of such tag can be ignored
-	skipTag bool
+type tagRunes []tagRune
 
-	buf []rune
+func (rs tagRunes) String() string {
+	sb := strings.Builder{}
+	for _, r := range rs {
+		sb.WriteRune(r.r)
+	}
+	return sb.String()
+}
 
-	key  string
-	vals Validations
+// index returns the index of the first rune in the set, or -1 if there is none.
+func (rs tagRunes) index(set string) int {
+	for i, r := range rs {
+		if strings.ContainsRune(set, r.r) {
+			return i
+		}
+	}
+	return -1
 }
 
-func newParseState() *parseState {
-	return &parseState{vals: make(Validations)}
+type tagParser struct {
+	groups Groups
+	// keys are the already parsed tag keys, which must not repeat
+	keys map[string]bool
+	// syntax are the tag keys and aliases of the rules
+	syntax Syntax
+	// byKey are the rules of each of the tag keys, merged into groups after parsing
+	byKey map[string]Groups
+	// end is the position of the closing quote of the literal, used for errors at the end of the input
+	end token.Position
 }
 
-// accept checks if the parseState can accept the rune c
-// and if possible, accumulates it into the buf.
-func (s *parseState) accept(c rune) error {
-	if s.isAt(end) {
-		return fmt.Errorf("already reached the end, got unexpected next rune")
+// parseTag parses the Groups of the rules of the tag keys of the syntax in the struct tag literal,
+// starting at the position pos in the source.
+func parseTag(literal string, pos token.Position, syntax Syntax) (Groups, error) {
+	p := &tagParser{groups: Groups{DefaultGroup: Validations{}}, keys: make(map[string]bool), syntax: syntax, byKey: map[string]Groups{}}
+	runes, end, err := unquote(literal, pos)
+	if err != nil {
+		return nil, err
 	}
 
-	switch c {
-	case '`':
-		return s.acceptRawQuote()
-	case ' ':
-		return s.acceptSpace()
-	case ':':
-		return s.acceptColon()
-	case '"':
-		return s.acceptQuote()
-	case ',':
-		return s.acceptComma()
-	case '=':
-		return s.acceptEqualSign()
+	p.end = end
+	if err = p.parse(runes); err != nil {
+		return nil, err
 	}
 
-	if s.isAt(begin) {
-		s.at = tagKey
-		s.buf = append(s.buf, c)
-		return nil
+	p.mergeKeys()
+	return p.groups, nil
+}
+
+// mergeKeys appends the rules of the tag keys to the groups in the priority order, see TagKeys.
+func (p *tagParser) mergeKeys() {
+	seen := map[string]map[string]bool{}
+	for _, key := range p.syntax.TagKeys {
+		for group, vals := range p.byKey[key] {
+			for _, alts := range vals {
+				if !seen[group][alts.Tag()] {
+					p.groups[group] = append(p.groups[group], alts)
+				}
+			}
+		}
+
+		for group, vals := range p.byKey[key] {
+			if seen[group] == nil {
+				seen[group] = map[string]bool{}
+			}
+
+			for _, alts := range vals {
+				seen[group][alts.Tag()] = true
+			}
+		}
 	}
+}
 
-	if s.isAt(tagKey) {
-		s.buf = append(s.buf, c)
-		return nil
+func (p *tagParser) parse(rs tagRunes) error {
+	for {
+		for len(rs) > 0 && rs[0].r == ' ' {
+			rs = rs[1:]
+		}
+
+		if len(rs) == 0 {
+			return nil
+		}
+
+		i := 0
+		for i < len(rs) && rs[i].r > ' ' && rs[i].r != ':' && rs[i].r != '"' && rs[i].r != 0x7f {
+			i++
+		}
+
+		if i == 0 {
+			return p.errorAt(rs, 0, "expected tag key, got %q", rs[0].r)
+		}
+
+		key := rs[:i].String()
+		if i == len(rs) || rs[i].r != ':' {
+			return p.errorAt(rs, i, "expected ':' after tag key %q", key)
+		}
+
+		if i+1 == len(rs) || rs[i+1].r != '"' {
+			return p.errorAt(rs, i+1, "expected '\"' after tag key %q", key)
+		}
+
+		j := i + 2
+		for j < len(rs) && rs[j].r != '"' {
+			if rs[j].r == '\\' {
+				j++
+			}
+			j++
+		}
+
+		if j >= len(rs) {
+			return p.errorAt(rs, i+1, "unterminated value of tag key %q", key)
+		}
+
+		if j+1 < len(rs) && rs[j+1].r != ' ' {
+			return p.errorAt(rs, j+1, "expected space after value of tag key %q", key)
+		}
+
+		if err := p.pair(key, rs[0], rs[i+1:j+1]); err != nil {
+			return err
+		}
+
+		rs = rs[j+1:]
 	}
+}
 
-	if s.isAt(pairKey | pairValue) {
-		s.buf = append(s.buf, c)
-		return nil
+// pair parses the tagValue of the tagKey starting at the rune at, quoted is the value including the quotes.
+func (p *tagParser) pair(key string, at tagRune, quoted tagRunes) error {
+	if p.keys[key] {
+		return &TagError{Pos: at.pos, Msg: fmt.Sprintf("duplicated tag key %q", key)}
 	}
+	p.keys[key] = true
 
-	if s.isAt(pairKeyValueSeparator) {
-		s.setAt(pairValue)
-		s.buf = append(s.buf, c)
-		return nil
+	tagKey, group := "", DefaultGroup
+	for _, k := range p.syntax.TagKeys {
+		if key == k {
+			tagKey = k
+			break
+		}
+
+		if g, qualified := strings.CutPrefix(key, k+"."); qualified && g != "" {
+			tagKey, group = k, g
+			break
+		}
 	}
 
-	if s.isAt(pairSeparator) && s.nextIs(pairKey) {
-		s.setAt(pairKey)
-		s.setNext(idk)
-		s.buf = append(s.buf, c)
+	if tagKey == "" {
 		return nil
 	}
 
-	return nil
+	value, err := unquoteRunes(quoted)
+	if err != nil {
+		return &TagError{Pos: quoted[0].pos, Msg: fmt.Sprintf("invalid value of tag key %q: %v", key, err)}
+	}
+
+	if p.byKey[tagKey] == nil {
+		p.byKey[tagKey] = Groups{}
+	}
+
+	// rules of the key are parsed into its own groups, sharing the map
+	keyParser := &tagParser{groups: p.byKey[tagKey], syntax: p.syntax}
+	return keyParser.value(group, value, quoted[len(quoted)-1])
 }
 
-func (s *parseState) acceptSpace() error {
-	switch {
-	case s.isAt(begin):
-		return nil
-	case s.isAt(tagValue):
-		s.buf = append(s.buf, ' ')
-		return nil
-	case s.isAt(tagKey):
-		return fmt.Errorf("space in tag key not allowed %s", s)
-	case s.isAt(tagSeparator):
-		return fmt.Errorf("space not allowed after tagSeparator, expected quote '\"' after: %q", s.buf)
-	case s.isAt(pairKey):
-		s.setAt(begin)
-		s.setNext(tagValue)
-		s.storeKey()
-		return nil
-	case s.isAt(pairValue):
-		s.buf = append(s.buf, ' ')
-		return nil
+// value parses the rules of the tagValue, closing is the closing quote.
+func (p *tagParser) value(tagGroup string, rs tagRunes, closing tagRune) error {
+	group := tagGroup
+	for len(rs) > 0 {
+		// group prefix, e.g. 'create' in `validate:"create:required"`
+		if colon := groupPrefix(rs); colon >= 0 {
+			if colon == 0 {
+				return &TagError{Pos: rs[0].pos, Msg: "expected group name before ':'"}
+			}
+
+			group = rs[:colon].String()
+			rs = rs[colon+1:]
+			if len(rs) == 0 {
+				return &TagError{Pos: closing.pos, Msg: fmt.Sprintf("expected rule of group %q", group)}
+			}
+
+			if rs[0].r == ',' || rs[0].r == ';' {
+				return &TagError{Pos: rs[0].pos, Msg: fmt.Sprintf("expected rule of group %q", group)}
+			}
+		}
+
+		end := ruleEnd(rs, group != tagGroup)
+		rule, sep := rs, closing
+		if end >= 0 {
+			rule, sep = rs[:end], rs[end]
+		}
+
+		if len(rule) == 0 {
+			return &TagError{Pos: sep.pos, Msg: fmt.Sprintf("expected rule before %q", sep.r)}
+		}
+
+		if err := p.rule(group, rule); err != nil {
+			return err
+		}
+
+		if end < 0 {
+			return nil
+		}
+
+		if sep.r == ';' {
+			group = tagGroup
+		}
+
+		rs = rs[end+1:]
+		if len(rs) == 0 {
+			return &TagError{Pos: closing.pos, Msg: fmt.Sprintf("expected rule after %q", sep.r)}
+		}
 	}
 
-	return fmt.Errorf("unexpected space %s", s)
+	return nil
 }
 
-func (s *parseState) acceptRawQuote() error {
-	switch s.rawQuotes {
-	case 0:
-		s.rawQuotes = 1
-		s.setAt(begin)
-		s.setNext(tagKey)
-		return nil
-	case 1:
-		s.rawQuotes = 2
-		s.setAt(end)
-		s.setNext(idk)
-		return nil
+// groupPrefix returns the index of the colon ending the group prefix of the rule, or -1 when the rule has no group.
+// The colon is the group prefix only before the name of the rule ends, e.g. 'regexp=^a:b$' has no group.
+func groupPrefix(rs tagRunes) int {
+	for i, r := range rs {
+		if r.r == ':' {
+			return i
+		}
+
+		if strings.ContainsRune("=|,; ", r.r) {
+			return -1
+		}
 	}
 
-	return fmt.Errorf("unexpected number of raw quotes: %d %s", s.rawQuotes, s)
+	return -1
 }
 
-func (s *parseState) String() string {
-	return fmt.Sprintf("[parse state at: %d, next: %d, buf: %s, key: %s, vals: %v]", s.at, s.next, string(s.buf), string(s.key), s.vals)
+// ruleEnd returns the index of the separator after the rule, or -1 when the rule is the last one. The semicolon ends
+// the group and precedes the next group, otherwise it is the part of the param, e.g. 'oneof=a;b', like in
+// go-playground/validator.
+func ruleEnd(rs tagRunes, grouped bool) int {
+	param := false
+	for i, r := range rs {
+		switch r.r {
+		case ',':
+			return i
+		case ';':
+			if grouped || !param || groupPrefix(rs[i+1:]) > 0 {
+				return i
+			}
+		case '=':
+			param = true
+		case '|':
+			param = false
+		}
+	}
+
+	return -1
 }
 
-func (s *parseState) acceptColon() error {
-	if s.isAt(tagKey) {
-		if string(s.buf) == "validate" {
-			s.setAt(tagSeparator)
-			s.setNext(tagValue)
-		} else {
-			s.skipTag = true
-			s.setNext(tagValue)
+// rule parses the alternatives separated with the pipe and stores them in the group.
+func (p *tagParser) rule(group string, rule tagRunes) error {
+	var alts Alternatives
+	for len(rule) > 0 {
+		end := rule.index("|")
+		alt := rule
+		if end >= 0 {
+			alt = rule[:end]
 		}
 
-		s.buf = nil
-		return nil
+		if len(alt) == 0 {
+			return &TagError{Pos: rule[0].pos, Msg: "expected rule before '|'"}
+		}
+
+		r, err := p.alternative(alt)
+		if err != nil {
+			return err
+		}
+
+		expanded, err := p.expand(r)
+		if err != nil {
+			return err
+		}
+
+		// the alias of multiple rules is stored as is, when it is not one of the alternatives
+		if len(expanded) > 1 && end < 0 && len(alts) == 0 {
+			p.groups[group] = append(p.groups[group], expanded...)
+			return nil
+		}
+
+		if len(expanded) > 1 {
+			return &TagError{Pos: r.Pos, Msg: fmt.Sprintf("alias %q of multiple rules can not be one of the alternatives", r.Name)}
+		}
+
+		alts = append(alts, expanded[0]...)
+		if end < 0 {
+			break
+		}
+
+		if end == len(rule)-1 {
+			return &TagError{Pos: rule[end].pos, Msg: "expected rule after '|'"}
+		}
+
+		rule = rule[end+1:]
 	}
 
-	return fmt.Errorf("unexpected colon: %s", s)
+	p.groups[group] = append(p.groups[group], alts)
+	return nil
 }
 
-func (s *parseState) acceptQuote() error {
-	if s.nextIs(tagValue) {
-		s.setAt(pairKey)
-		s.setNext(pairKey)
-		return nil
+// expand returns the rules of the alias, see Aliases, or the rule itself, when it is not an alias.
+// The expanded rules are at the position of the alias.
+func (p *tagParser) expand(r Rule) (Validations, error) {
+	alias, ok := p.syntax.Aliases[r.Name]
+	if !ok {
+		return Validations{{r}}, nil
 	}
 
-	if s.skipTag {
-		return nil
+	if r.Param != "" {
+		return nil, &TagError{Pos: r.Pos, Msg: fmt.Sprintf("alias %q has no parameter", r.Name)}
 	}
 
-	if s.isAt(pairKey) {
-		s.setNext(pairKeyValueSeparator | tagValue)
-		s.storeKey()
-		return nil
+	rs := make(tagRunes, 0, len(alias))
+	for _, c := range alias {
+		rs = append(rs, tagRune{r: c, pos: r.Pos})
 	}
 
-	if s.isAt(pairValue) {
-		s.storeValue()
-		return nil
+	aliasParser := &tagParser{groups: Groups{}}
+	if err := aliasParser.value(DefaultGroup, rs, tagRune{pos: r.Pos}); err != nil {
+		return nil, fmt.Errorf("alias %q: %w", r.Name, err)
 	}
 
-	return fmt.Errorf("unexpected quote after: %q, at: %d", s.buf, s.at)
-}
-
-func (s *parseState) acceptComma() error {
-	if s.isAt(pairKey) {
-		s.storeKey()
-		s.setNext(pairKey)
-		return nil
+	if _, ok := aliasParser.groups[DefaultGroup]; !ok || len(aliasParser.groups) != 1 {
+		return nil, &TagError{Pos: r.Pos, Msg: fmt.Sprintf("alias %q can not declare groups", r.Name)}
 	}
 
-	if s.isAt(pairValue) {
-		s.storeValue()
-		s.setNext(pairKey)
-		s.setAt(pairSeparator)
-		return nil
+	expanded := aliasParser.groups[DefaultGroup]
+	for _, alts := range expanded {
+		for i := range alts {
+			alts[i].Alias = r.Name
+		}
 	}
 
-	return fmt.Errorf("unexpected comma %s", s)
+	return expanded, nil
 }
 
-func (s *parseState) acceptEqualSign() error {
-	if !s.isAt(pairKey) {
-		return fmt.Errorf("unexpected equal sign %s", s)
+// alternative parses the boolean attribute or key-value pair.
+func (p *tagParser) alternative(rule tagRunes) (Rule, error) {
+	key, param := rule, tagRunes(nil)
+	if eq := rule.index("="); eq >= 0 {
+		key, param = rule[:eq], rule[eq+1:]
+		if len(param) == 0 {
+			return Rule{}, &TagError{Pos: rule[eq].pos, Msg: fmt.Sprintf("expected value of rule %q after '='", key)}
+		}
 	}
 
-	s.storeKey()
-	s.setAt(pairKeyValueSeparator)
-	s.setNext(pairValue)
-	return nil
-}
+	if len(key) == 0 {
+		return Rule{}, &TagError{Pos: rule[0].pos, Msg: "expected rule name"}
+	}
 
-func (s *parseState) storeKey() {
-	if len(s.buf) == 0 {
-		return
+	if i := key.index(" \t"); i >= 0 {
+		return Rule{}, &TagError{Pos: key[i].pos, Msg: fmt.Sprintf("unexpected space in rule name %q", key)}
 	}
 
-	s.key = string(s.buf)
-	s.vals[s.key] = append(s.vals[s.key], make([]string, 0)...)
-	s.buf = nil
+	return Rule{Name: key.String(), Param: unescape(param.String()), Pos: rule[0].pos}, nil
 }
 
-func (s *parseState) storeValue() {
-	if len(s.buf) == 0 {
-		return
+func (p *tagParser) errorAt(rs tagRunes, i int, format string, args ...any) error {
+	pos := p.end
+	if i < len(rs) {
+		pos = rs[i].pos
 	}
 
-	s.vals[s.key] = append(s.vals[s.key], string(s.buf))
-	s.buf = nil
+	return &TagError{Pos: pos, Msg: fmt.Sprintf(format, args...)}
 }
 
-func (s *parseState) isAt(w where) bool {
-	return s.at&w != 0
+func unescape(param string) string {
+	return strings.NewReplacer(escapedComma, ",", escapedPipe, "|", escapedSemicolon, ";").Replace(param)
 }
 
-func (s *parseState) nextIs(w where) bool {
-	return s.next&w != 0
-}
+// unquote unquotes the Go string literal, keeping the position of every rune in the source.
+// It returns the position of the closing quote as well.
+func unquote(literal string, pos token.Position) (tagRunes, token.Position, error) {
+	src := make(tagRunes, 0, len(literal))
+	for _, r := range literal {
+		src = append(src, tagRune{r: r, pos: pos})
+		pos.Offset += utf8.RuneLen(r)
+		pos.Column += utf8.RuneLen(r)
+		if r == '\n' {
+			pos.Line++
+			pos.Column = 1
+		}
+	}
 
-// smell of Java, because you can add debug logging here XD
-func (s *parseState) setAt(w where) {
-	s.at = w
-}
+	if len(src) < 2 || src[0].r != src[len(src)-1].r || (src[0].r != '`' && src[0].r != '"') {
+		start := pos
+		if len(src) > 0 {
+			start = src[0].pos
+		}
+
+		return nil, pos, &TagError{Pos: start, Msg: fmt.Sprintf("expected string literal, got: %s", literal)}
+	}
 
-func (s *parseState) setNext(w where) {
-	s.next = w
+	closing := src[len(src)-1].pos
+	if src[0].r == '`' {
+		return src[1 : len(src)-1], closing, nil
+	}
+
+	rs, err := unquoteRunes(src)
+	if err != nil {
+		return nil, closing, &TagError{Pos: src[0].pos, Msg: err.Error()}
+	}
+
+	return rs, closing, nil
 }
 
-func (s *parseState) build() (Validations, error) {
-	return s.vals, nil
+// unquoteRunes unquotes the interpreted string literal, each rune is at the position of its first source rune.
+func unquoteRunes(quoted tagRunes) (tagRunes, error) {
+	inner := quoted[1 : len(quoted)-1]
+	s := inner.String()
+	out := make(tagRunes, 0, len(inner))
+	consumed := 0
+	for len(s) > 0 {
+		r, _, tail, err := strconv.UnquoteChar(s, '"')
+		if err != nil {
+			return nil, fmt.Errorf("unquoting %s: %w", quoted, err)
+		}
+
+		out = append(out, tagRune{r: r, pos: inner[consumed].pos})
+		consumed += utf8.RuneCountInString(s) - utf8.RuneCountInString(tail)
+		s = tail
+	}
+
+	return out, nil
 }
@@ -0,0 +1,282 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/types"
+	"strconv"
+	"strings"
+)
+
+// ZodSchemaName returns the name of the TypeScript constant with the Zod schema of the struct.
+func ZodSchemaName(name string) string {
+	return name + "Schema"
+}
+
+// zodExpr is the Zod schema expression of the field, e.g. `z.string().min(3)`.
+// Checks are the methods of the base schema, refines are appended after them, because they change the type of the schema.
+type zodExpr struct {
+	base    string
+	checks  []string
+	refines []string
+}
+
+func (e zodExpr) String() string {
+	return e.base + strings.Join(e.checks, "") + strings.Join(e.refines, "")
+}
+
+// ZodSchemas returns the TypeScript module with the Zod schema and the inferred type of every struct.
+// Properties are named after the json tag, fields ignored with `json:"-"` and unexported fields are skipped,
+// fields without the 'required' rule are optional and nested structs refer to their schemas lazily.
+// The rules are checked with the generators first, so the rules rejected by GenerateFile fail the export.
+// Rules without the equivalent in Zod, like eqfield, are listed in the WARNING comments of the properties and returned
+// as the warnings, so the frontend and the generated code do not disagree silently. Lengths of the strings are counted
+// in the code points, like the generated code counts the runes.
+func ZodSchemas(structs []Struct) ([]byte, []string, error) {
+	b := &zodBuilder{known: make(map[string]bool, len(structs))}
+	for _, s := range structs {
+		b.known[s.Name] = true
+	}
+
+	out := &bytes.Buffer{}
+	out.WriteString("// Code generated by validator. DO NOT EDIT.\n\n")
+	out.WriteString("import { z } from \"zod\";\n")
+	for _, str := range structs {
+		if err := b.object(out, str); err != nil {
+			return nil, nil, fmt.Errorf("struct: %q, %w", str.Name, err)
+		}
+	}
+
+	return out.Bytes(), b.warnings, nil
+}
+
+type zodBuilder struct {
+	known    map[string]bool
+	warnings []string
+}
+
+// object writes the schema of the struct, with the properties of all the fields of the struct.
+func (b *zodBuilder) object(out *bytes.Buffer, str Struct) error {
+	validated := make(map[string]Field, len(str.Fields))
+	for _, f := range str.Fields {
+		validated[f.Name] = f
+	}
+
+	fmt.Fprintf(out, "\nexport const %s = z.object({\n", ZodSchemaName(str.Name))
+	for _, astField := range str.Ast.Fields.List {
+		for _, ident := range astField.Names {
+			if !ident.IsExported() {
+				continue
+			}
+
+			name, ok := jsonName(ident.Name, astField)
+			if !ok {
+				continue
+			}
+
+			field, ok := validated[ident.Name]
+			if !ok {
+				field = Field{Name: ident.Name, Type: Type(types.ExprString(astField.Type)), Ast: astField}
+			}
+
+			if err := checkRules(str, field); err != nil {
+				return fmt.Errorf("field: %q, %w", field.Name, err)
+			}
+
+			expr, skipped, err := b.property(field)
+			if err != nil {
+				return fmt.Errorf("field: %q, %w", field.Name, err)
+			}
+
+			for _, reason := range skipped {
+				warning := fmt.Sprintf("%s.%s: %s", str.Name, name, reason)
+				b.warnings = append(b.warnings, warning)
+				fmt.Fprintf(out, "\t// WARNING: %s\n", warning)
+			}
+
+			fmt.Fprintf(out, "\t%s: %s,\n", zodKey(name), expr)
+		}
+	}
+
+	fmt.Fprintf(out, "});\n\nexport type %s = z.infer<typeof %s>;\n", str.Name, ZodSchemaName(str.Name))
+	return nil
+}
+
+// property returns the schema of the field and the reasons of skipping the rules without the equivalent in Zod.
+func (b *zodBuilder) property(field Field) (string, []string, error) {
+	expr, skipped, err := b.schema(field.Type, field.Validations)
+	if err != nil {
+		return "", nil, err
+	}
+
+	switch {
+	case isRequired(field.Validations):
+	case field.Type.IsPtr():
+		expr.refines = append(expr.refines, ".nullish()")
+	default:
+		expr.refines = append(expr.refines, ".optional()")
+	}
+
+	return expr.String(), skipped, nil
+}
+
+// isRequired returns true, when the validations of the field, without the validations of its elements, have the rule
+// Required, which is not one of the alternatives.
+func isRequired(vals Validations) bool {
+	vals, _, _ = vals.Dive()
+	for _, alts := range vals {
+		if len(alts) == 1 && alts[0].Name == Required {
+			return true
+		}
+	}
+
+	return false
+}
+
+// schema returns the schema of the type with the rules applied and the reasons of skipping the other rules.
+// The rules after Dive are applied to the schema of the elements, which are nullable pointers unless required.
+func (b *zodBuilder) schema(t Type, vals Validations) (zodExpr, []string, error) {
+	expr := zodExpr{base: b.typeExpr(t)}
+	vals, elems, dive := vals.Dive()
+	var skipped []string
+	if dive && t.IsSlice() {
+		elem, elemSkipped, err := b.schema(t.Elem(), elems)
+		if err != nil {
+			return zodExpr{}, nil, err
+		}
+
+		if t.Elem().IsPtr() && !isRequired(elems) {
+			elem.refines = append(elem.refines, ".nullable()")
+		}
+
+		expr.base = fmt.Sprintf("z.array(%s)", elem)
+		skipped = append(skipped, elemSkipped...)
+	}
+
+	for _, alts := range vals {
+		if len(alts) == 1 {
+			if !zodRule(&expr, t, alts[0]) {
+				skipped = append(skipped, fmt.Sprintf("rule %q has no Zod equivalent", alts.String()))
+			}
+			continue
+		}
+
+		union := make([]string, 0, len(alts))
+		for _, rule := range alts {
+			alt := zodExpr{base: expr.base}
+			if !zodRule(&alt, t, rule) {
+				union = nil
+				skipped = append(skipped, fmt.Sprintf("rule %q has no Zod equivalent", alts.String()))
+				break
+			}
+			union = append(union, alt.String())
+		}
+
+		if len(union) != 0 {
+			expr.refines = append(expr.refines, fmt.Sprintf(".refine((v) => z.union([%s]).safeParse(v).success)", strings.Join(union, ", ")))
+		}
+	}
+
+	return expr, skipped, nil
+}
+
+// zodRule applies the rule to the schema of the type, it returns false if the rule has no equivalent in Zod.
+// Lengths of the strings are refined with the count of the code points, because the methods of Zod count UTF-16 units.
+// Required pointers are only not nullish, see property, like the generated code compares them with nil.
+func zodRule(e *zodExpr, t Type, rule Rule) bool {
+	switch rule.Name {
+	case Required:
+		if t.IsString() || t.IsSlice() {
+			e.checks = append(e.checks, ".min(1)")
+		}
+		if t.IsMap() {
+			e.refines = append(e.refines, ".refine((v) => Object.keys(v).length > 0)")
+		}
+		return true
+	case Oneof:
+		values := oneofValues(rule.Param)
+		if t.IsString() {
+			values = mapSlice(values, jsString)
+		}
+		e.refines = append(e.refines, fmt.Sprintf(".refine((v) => [%s].includes(v))", strings.Join(values, ", ")))
+		return true
+	case Email:
+		e.checks = append(e.checks, ".email()")
+		return true
+	case URL:
+		e.checks = append(e.checks, ".url()")
+		return true
+	case Regexp:
+		e.checks = append(e.checks, fmt.Sprintf(".regex(new RegExp(%s))", jsString(rule.Param)))
+		return true
+	case Dive:
+		return true
+	case Min, Max, Len:
+		n, err := strconv.ParseFloat(rule.Param, 64)
+		if err != nil {
+			return false
+		}
+
+		op := map[string]string{Min: ">=", Max: "<=", Len: "==="}[rule.Name]
+		switch {
+		case t.IsString():
+			e.refines = append(e.refines, fmt.Sprintf(".refine((v) => [...v].length %s %d)", op, int(n)))
+		case t.IsSlice():
+			e.checks = append(e.checks, fmt.Sprintf(".%s(%d)", map[string]string{Min: "min", Max: "max", Len: "length"}[rule.Name], int(n)))
+		case t.IsNumber() && rule.Name != Len:
+			e.checks = append(e.checks, fmt.Sprintf(".%s(%s)", map[string]string{Min: "gte", Max: "lte"}[rule.Name], rule.Param))
+		case t.IsMap():
+			e.refines = append(e.refines, fmt.Sprintf(".refine((v) => Object.keys(v).length %s %d)", op, int(n)))
+		default:
+			return false
+		}
+		return true
+	}
+
+	return false
+}
+
+// typeExpr returns the schema of the Go type.
+func (b *zodBuilder) typeExpr(t Type) string {
+	switch base := t.Deref(); {
+	case base.IsString():
+		return "z.string()"
+	case base.IsBool():
+		return "z.boolean()"
+	case base.IsInteger():
+		return "z.number().int()"
+	case base.IsNumber():
+		return "z.number()"
+	case base.IsSlice():
+		return fmt.Sprintf("z.array(%s)", b.typeExpr(base.Elem()))
+	case base.IsMap():
+		return fmt.Sprintf("z.record(z.string(), %s)", b.typeExpr(base.Elem()))
+	case b.known[string(base)]:
+		return fmt.Sprintf("z.lazy(() => %s)", ZodSchemaName(string(base)))
+	}
+
+	return "z.unknown()"
+}
+
+// zodKey returns the key of the property, quoted if it is not a valid identifier.
+func zodKey(name string) string {
+	for i, r := range name {
+		if r != '_' && r != '$' && !('a' <= r && r <= 'z') && !('A' <= r && r <= 'Z') && (i == 0 || !('0' <= r && r <= '9')) {
+			return jsString(name)
+		}
+	}
+
+	return name
+}
+
+// jsString returns the JavaScript string literal, JSON strings are valid in JavaScript.
+func jsString(s string) string {
+	quoted, err := json.Marshal(s)
+	if err != nil {
+		// unreachable, strings are always valid JSON
+		return strconv.Quote(s)
+	}
+
+	return string(quoted)
+}
@@ -0,0 +1,107 @@
+package internal_test
+
+import (
+	"go/parser"
+	"go/token"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/paluszkiewiczB/validator/internal"
+)
+
+func Test_ZodSchemas(t *testing.T) {
+	internal.Log = newTestLog(t)
+
+	src := "package example\n" +
+		"type Order struct {\n" +
+		"	ID       string            `json:\"id\" validate:\"required,len=26\"`\n" +
+		"	Status   string            `json:\"status,omitempty\" validate:\"oneof=new paid\"`\n" +
+		"	Email    *string           `json:\"email\" validate:\"required\"`\n" +
+		"	Contact  string            `json:\"contact\" validate:\"email|url\"`\n" +
+		"	Code     string            `validate:\"len=0|min=2,max=8,regexp=^[A-Z/]+$\"`\n" +
+		"	Quantity int               `json:\"quantity\" validate:\"min=1,gte=Min,oneof=1 10\"`\n" +
+		"	Tags     []string          `json:\"tags\" validate:\"max=5,dive,required,eqfield=ID\"`\n" +
+		"	Items    []Item            `json:\"items\" validate:\"required\"`\n" +
+		"	Labels   map[string]string `json:\"x-labels\" validate:\"max=3\"`\n" +
+		"	Min      int               `json:\"-\"`\n" +
+		"	internal string\n" +
+		"}\n" +
+		"type Item struct {\n" +
+		"	Price float64 `json:\"price\" validate:\"min=1.5\"`\n" +
+		"}\n"
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "example.go", src, 0)
+	if err != nil {
+		t.Fatalf("parsing source: %v", err)
+	}
+
+	structs, err := internal.FindStructs(fset, f)
+	if err != nil {
+		t.Fatalf("finding structs: %v", err)
+	}
+
+	content, warnings, err := internal.ZodSchemas(structs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `// Code generated by validator. DO NOT EDIT.
+
+import { z } from "zod";
+
+export const ItemSchema = z.object({
+	price: z.number().gte(1.5).optional(),
+});
+
+export type Item = z.infer<typeof ItemSchema>;
+
+export const OrderSchema = z.object({
+	id: z.string().min(1).refine((v) => [...v].length === 26),
+	status: z.string().refine((v) => ["new", "paid"].includes(v)).optional(),
+	email: z.string(),
+	contact: z.string().refine((v) => z.union([z.string().email(), z.string().url()]).safeParse(v).success).optional(),
+	Code: z.string().regex(new RegExp("^[A-Z/]+$")).refine((v) => z.union([z.string().refine((v) => [...v].length === 0), z.string().refine((v) => [...v].length >= 2)]).safeParse(v).success).refine((v) => [...v].length <= 8).optional(),
+	// WARNING: Order.quantity: rule "gte=Min" has no Zod equivalent
+	quantity: z.number().int().gte(1).refine((v) => [1, 10].includes(v)).optional(),
+	// WARNING: Order.tags: rule "eqfield=ID" has no Zod equivalent
+	tags: z.array(z.string().min(1)).max(5).optional(),
+	items: z.array(z.lazy(() => ItemSchema)).min(1),
+	"x-labels": z.record(z.string(), z.string()).refine((v) => Object.keys(v).length <= 3).optional(),
+});
+
+export type Order = z.infer<typeof OrderSchema>;
+`
+	if string(content) != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, content)
+	}
+
+	expectedWarnings := []string{
+		`Order.quantity: rule "gte=Min" has no Zod equivalent`,
+		`Order.tags: rule "eqfield=ID" has no Zod equivalent`,
+	}
+	if !reflect.DeepEqual(expectedWarnings, warnings) {
+		t.Errorf("expected warnings %q, got: %q", expectedWarnings, warnings)
+	}
+}
+
+func Test_ZodSchemas_RejectedRule(t *testing.T) {
+	internal.Log = newTestLog(t)
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "example.go", "package example\ntype User struct {\n\tNick *string `validate:\"min=3\"`\n}\n", 0)
+	if err != nil {
+		t.Fatalf("parsing source: %v", err)
+	}
+
+	structs, err := internal.FindStructs(fset, f)
+	if err != nil {
+		t.Fatalf("finding structs: %v", err)
+	}
+
+	_, _, err = internal.ZodSchemas(structs)
+	if err == nil || !strings.Contains(err.Error(), `unsupported type for validation: "min", type: "*string"`) {
+		t.Errorf("expected error of the rule rejected by the generator, got: %v", err)
+	}
+}
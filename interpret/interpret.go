@@ -0,0 +1,190 @@
+// Package interpret validates the structs at runtime via reflection, with the rules of the same validate tags,
+// which the generator reads. It is the fallback for the types the code can not be generated for, e.g. the structs
+// of third-party packages, and the reference of the generated code in the differential tests.
+// The rules are evaluated with the registry of the generators, so both accept the same rules with the same semantics.
+// Unlike the generated code, it reads only the tags: the +validate markers, the mod tags and the validation of the
+// fields of the type parameters with their method Validate are not available at runtime.
+package interpret
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"golang.org/x/exp/maps"
+
+	"github.com/paluszkiewiczB/validator/internal"
+)
+
+// Interpreter validates the structs with the rules of their validate tags.
+// The zero value reports the Go names of the fields, like the generator without the -name-tag and -tag-keys flags.
+type Interpreter struct {
+	// NameTag is the struct tag key with the names of the fields reported in the errors, e.g. 'json'.
+	NameTag string
+	// TagKeys are the struct tag keys of the rules in the priority order, like the -tag-keys flag of the generator.
+	// When empty, the rules are read from the 'validate' tags.
+	TagKeys []string
+	// Aliases are the custom rules expanded into the rules of the value, like the aliases of the config file,
+	// e.g. {"iscolor": "hexcolor|rgb|rgba"}.
+	Aliases map[string]string
+	// CollectAll returns the first violated rule of every field as validation.FieldErrors, like the generator
+	// with the collect-all errors.
+	CollectAll bool
+}
+
+// Validate validates the struct, or the pointer to the struct, with the Interpreter with no NameTag.
+func Validate(v any) error {
+	return Interpreter{}.Validate(v)
+}
+
+// Validate returns *validation.FieldError of the first rule of the default group, which the struct violates,
+// like the generated method Validate, or validation.FieldErrors with CollectAll.
+func (i Interpreter) Validate(v any) error {
+	return i.ValidateGroup(v, internal.DefaultGroup)
+}
+
+// ValidateGroup returns *validation.FieldError of the first rule of the group, which the struct violates,
+// like the generated method ValidateGroup, or validation.FieldErrors with CollectAll.
+func (i Interpreter) ValidateGroup(v any, group string) error {
+	value := reflect.ValueOf(v)
+	for value.Kind() == reflect.Pointer && !value.IsNil() {
+		value = value.Elem()
+	}
+
+	if value.Kind() != reflect.Struct {
+		return fmt.Errorf("expected struct, got: %T", v)
+	}
+
+	str, err := i.parse(value.Type())
+	if err != nil {
+		return err
+	}
+
+	if group != internal.DefaultGroup && !slices.Contains(str.Groups(), group) {
+		return fmt.Errorf("unknown validation group: %q", group)
+	}
+
+	return internal.EvaluateStruct(str, group, value, i.CollectAll)
+}
+
+// structKey is the key of the parsed structs.
+type structKey struct {
+	typ     reflect.Type
+	nameTag string
+	tagKeys string
+	aliases string
+}
+
+// aliasesKey returns the aliases in the comparable form of the structKey.
+func aliasesKey(aliases map[string]string) string {
+	decls := make([]string, 0, len(aliases))
+	for name, rules := range aliases {
+		decls = append(decls, name+"="+rules)
+	}
+
+	slices.Sort(decls)
+	return strings.Join(decls, "\n")
+}
+
+// structs caches the parsed internal.Struct, or the error of parsing it, by structKey.
+var structs sync.Map
+
+// parse returns the struct with the fields parsed from the validate tags, like internal.FindStructs.
+func (i Interpreter) parse(t reflect.Type) (internal.Struct, error) {
+	key := structKey{typ: t, nameTag: i.NameTag, tagKeys: strings.Join(i.TagKeys, ","), aliases: aliasesKey(i.Aliases)}
+	if cached, ok := structs.Load(key); ok {
+		if err, ok := cached.(error); ok {
+			return internal.Struct{}, err
+		}
+
+		return cached.(internal.Struct), nil
+	}
+
+	str, err := i.parseStruct(t)
+	if err != nil {
+		structs.Store(key, err)
+		return internal.Struct{}, err
+	}
+
+	structs.Store(key, str)
+	return str, nil
+}
+
+func (in Interpreter) parseStruct(t reflect.Type) (internal.Struct, error) {
+	str := internal.Struct{Name: t.Name()}
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		if sf.Tag == "" {
+			continue
+		}
+
+		tag := strconv.Quote(string(sf.Tag))
+		keys := in.TagKeys
+		if len(keys) == 0 {
+			keys = []string{internal.DefaultTagKey}
+		}
+
+		groups, err := internal.ParseGroupsOf(tag, token.Position{Line: 1, Column: 1}, internal.Syntax{TagKeys: keys, Aliases: in.Aliases})
+		if err != nil {
+			return internal.Struct{}, fmt.Errorf("struct: %q, field: %q, parsing validations: %w", t, sf.Name, err)
+		}
+
+		vals := groups[internal.DefaultGroup]
+		delete(groups, internal.DefaultGroup)
+		if len(vals) == 0 && len(groups) == 0 {
+			continue
+		}
+
+		if !sf.IsExported() {
+			return internal.Struct{}, fmt.Errorf("struct: %q, field: %q, %w", t, sf.Name, errUnexported)
+		}
+
+		field := internal.Field{Name: sf.Name, Type: typeOf(sf.Type, t.PkgPath()), Validations: vals}
+		if len(groups) != 0 {
+			field.Groups = groups
+		}
+
+		field.Messages, err = internal.ParseMessages(tag, append(maps.Values(groups), vals)...)
+		if err != nil {
+			return internal.Struct{}, fmt.Errorf("struct: %q, field: %q, parsing messages: %w", t, sf.Name, err)
+		}
+
+		if in.NameTag != "" {
+			field.ExternalName, err = internal.ParseName(tag, in.NameTag)
+			if err != nil {
+				return internal.Struct{}, fmt.Errorf("struct: %q, field: %q, parsing name: %w", t, sf.Name, err)
+			}
+		}
+
+		str.Fields = append(str.Fields, field)
+	}
+
+	return str, nil
+}
+
+var errUnexported = errors.New("validating unexported fields is not supported")
+
+// typeOf returns the type as written in the source of the package pkg, e.g. '*string' or 'time.Time'.
+func typeOf(t reflect.Type, pkg string) internal.Type {
+	switch {
+	case t.Name() != "" && t.PkgPath() == pkg:
+		return internal.Type(t.Name())
+	case t.Name() != "":
+		return internal.Type(t.String())
+	case t.Kind() == reflect.Pointer:
+		return "*" + typeOf(t.Elem(), pkg)
+	case t.Kind() == reflect.Slice:
+		return "[]" + typeOf(t.Elem(), pkg)
+	case t.Kind() == reflect.Array:
+		return internal.Type(fmt.Sprintf("[%d]", t.Len())) + typeOf(t.Elem(), pkg)
+	case t.Kind() == reflect.Map:
+		return "map[" + typeOf(t.Key(), pkg) + "]" + typeOf(t.Elem(), pkg)
+	}
+
+	return internal.Type(t.String())
+}
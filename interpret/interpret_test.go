@@ -0,0 +1,160 @@
+package interpret_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/paluszkiewiczB/validator/interpret"
+	"github.com/paluszkiewiczB/validator/validation"
+)
+
+type user struct {
+	Name     string  `json:"name" validate:"required,max=3"`
+	Password string  `validate:"required;create:required,eqfield=Repeated"`
+	Repeated string  `validate:"create:required"`
+	Age      *uint8  `validate:"required"`
+	Score    float32 `validate:"min=0.5" msg:"{field} is too low: {value}"`
+}
+
+func validUser() user {
+	return user{Name: "abc", Password: "secret", Age: new(uint8), Score: 0.5}
+}
+
+func Test_Validate(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		v := validUser()
+		for _, v := range []any{v, &v} {
+			if err := interpret.Validate(v); err != nil {
+				t.Errorf("expected no error, got: %v", err)
+			}
+		}
+	})
+
+	cases := map[string]struct {
+		mutate func(u *user)
+		group  string
+		field  string
+		tag    string
+		msg    string
+	}{
+		"required": {
+			mutate: func(u *user) { u.Name = "" },
+			field:  "name", tag: "required", msg: `field "name" is required`,
+		},
+		"max in runes": {
+			mutate: func(u *user) { u.Name = "żółwi" },
+			field:  "name", tag: "max", msg: `field "name" must be at most 3`,
+		},
+		"nil pointer": {
+			mutate: func(u *user) { u.Age = nil },
+			field:  "Age", tag: "required", msg: `field "Age" is required`,
+		},
+		"message with value": {
+			mutate: func(u *user) { u.Score = 0.25 },
+			field:  "Score", tag: "min", msg: "Score is too low: 0.25",
+		},
+		"group": {
+			mutate: func(u *user) { u.Repeated = "other" },
+			group:  "create",
+			field:  "Password", tag: "eqfield", msg: `field "Password" must be equal to "Repeated"`,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			v := validUser()
+			c.mutate(&v)
+
+			var fieldErr *validation.FieldError
+			err := interpret.Interpreter{NameTag: "json"}.ValidateGroup(v, c.group)
+			if !errors.As(err, &fieldErr) {
+				t.Fatalf("expected validation.FieldError, got: %v", err)
+			}
+
+			if fieldErr.Field != c.field || fieldErr.Tag != c.tag || fieldErr.Message != c.msg {
+				t.Errorf("expected field %q, tag %q and message %q, got: %#v", c.field, c.tag, c.msg, fieldErr)
+			}
+		})
+	}
+}
+
+func Test_Validate_CollectAll(t *testing.T) {
+	v := validUser()
+	v.Name, v.Age, v.Score = "", nil, 0.25
+
+	err := interpret.Interpreter{CollectAll: true}.Validate(v)
+	errs, ok := err.(validation.FieldErrors)
+	if !ok || len(errs) != 3 {
+		t.Fatalf("expected the errors of 3 fields, got: %v", err)
+	}
+
+	for i, expected := range []string{"Name", "Age", "Score"} {
+		if errs[i].Field != expected {
+			t.Errorf("expected error %d of field %q, got: %#v", i, expected, errs[i])
+		}
+	}
+
+	if err := interpret.Validate(v); err == nil || err.Error() != `field "Name" is required` {
+		t.Errorf("expected the first error only, got: %v", err)
+	}
+}
+
+func Test_Validate_TagKeys(t *testing.T) {
+	type form struct {
+		Name string `binding:"required,max=3" validate:"max=5"`
+	}
+
+	in := interpret.Interpreter{TagKeys: []string{"binding", "validate"}}
+	if err := in.Validate(form{Name: "abcd"}); err == nil || err.Error() != `field "Name" must be at most 3` {
+		t.Errorf("expected the rule of binding, got: %v", err)
+	}
+
+	if err := interpret.Validate(form{Name: "abcd"}); err != nil {
+		t.Errorf("expected only the validate tag, got: %v", err)
+	}
+}
+
+func Test_Validate_Aliases(t *testing.T) {
+	type form struct {
+		Name string `validate:"name"`
+	}
+
+	in := interpret.Interpreter{Aliases: map[string]string{"name": "required,max=3"}}
+	var fieldErr *validation.FieldError
+	if err := in.Validate(form{Name: "abcd"}); !errors.As(err, &fieldErr) || fieldErr.Tag != "name" || fieldErr.ActualTag != "max" {
+		t.Errorf("expected tag name and actual tag max, got: %v", err)
+	}
+}
+
+func Test_Validate_Errors(t *testing.T) {
+	type unknown struct {
+		ID string `validate:"uuid"`
+	}
+
+	type unsupported struct {
+		Count int `validate:"required"`
+	}
+
+	type unexported struct {
+		name string `validate:"required"`
+	}
+
+	for name, c := range map[string]struct {
+		v     any
+		group string
+		err   string
+	}{
+		"not a struct":  {v: "abc", err: "expected struct, got: string"},
+		"unknown group": {v: validUser(), group: "update", err: `unknown validation group: "update"`},
+		"unknown rule":  {v: unknown{}, err: `validator not found for struct: "unknown", field: "ID", validation: "uuid"`},
+		"unsupported":   {v: unsupported{}, err: `unsupported type for validation: "required"`},
+		"unexported":    {v: unexported{name: "a"}, err: `struct: "interpret_test.unexported", field: "name", validating unexported fields is not supported`},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := interpret.Interpreter{}.ValidateGroup(c.v, c.group)
+			if err == nil || err.Error() != c.err {
+				t.Errorf("expected error %q, got: %v", c.err, err)
+			}
+		})
+	}
+}
@@ -2,131 +2,374 @@
 package main
 
 import (
+	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"go/ast"
-	"go/format"
+	"go/build"
 	"go/parser"
 	"go/token"
-	"golang.org/x/exp/slices"
+	"io/fs"
 	"log"
 	"os"
-	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
 
-	"github.com/paluszkiewiczB/validator/internal"
+	"golang.org/x/mod/modfile"
 
-	"golang.org/x/tools/go/ast/astutil"
+	"github.com/paluszkiewiczB/validator/internal"
 )
 
 var (
-	srcFile = flag.String("in", "main.go", "input file")
-	dstFile = flag.String("out", "generated.go", "output file")
-	dstPkg  = flag.String("outpkg", "main", "output package")
-	debug   = flag.Bool("debug", false, "debug logs enabled")
+	srcFile  = flag.String("in", "main.go", "input file")
+	dstFile  = flag.String("out", "generated.go", "output file")
+	dstPkg   = flag.String("outpkg", "main", "output package")
+	debug    = flag.Bool("debug", false, "debug logs enabled")
+	catalog  = flag.String("catalog", "", "output JSON file with the translation catalog of the error messages")
+	nameTag  = flag.String("name-tag", "", "tag with the field names reported in the errors, e.g. json, form, query or yaml")
+	schemas  = flag.String("jsonschema", "", "output directory of the JSON Schemas of the structs")
+	openAPI  = flag.String("openapi", "", "OpenAPI 3.1 document in JSON, the schemas of the structs are merged into its components.schemas")
+	zodFile  = flag.String("zod", "", "output TypeScript file with the Zod schemas of the structs")
+	protoOut = flag.String("proto", "", "output .proto file with the messages of the structs and protovalidate constraints")
+	protoPkg = flag.String("proto-go-package", "", "option go_package of the .proto file, by default the import path of its directory in the module")
+	protoIn  = flag.String("from-proto", "", "input .proto file with protovalidate constraints, validations are generated for its messages instead of the input file")
+	sqlFile  = flag.String("sql", "", "output PostgreSQL migration with the CHECK constraints of the structs with db tags")
+	genTests = flag.String("gen-tests", "", "output test file with the minimal valid instance of every struct and the cases breaking each rule")
+	genFuzz  = flag.String("gen-fuzz", "", "output test file with the fuzz test of Validate of every struct")
+	tagKeys  = flag.String("tag-keys", internal.DefaultTagKey, "comma separated struct tag keys of the rules in the priority order, e.g. binding,validate")
+	receiver = flag.String("receiver", string(internal.AutoReceiver), "receiver of the generated methods: auto, pointer or value, auto uses the pointer receiver when the type has any method with the pointer receiver")
+	unexport = flag.Bool("unexported", false, "generate the unexported methods validate and validateGroup, so the package can wrap them")
+	errStyle = flag.String("errors", string(internal.FailFast), "errors returned by the generated methods: fail-fast returns the first one, collect-all the first one of every field")
+	msgFile  = flag.String("messages", "", "JSON file with the templates of error messages per validation, e.g. {\"required\": \"{field} is required\"}")
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		Must(runMigrate(os.Args[2:]))
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "config" {
+		Must(runConfig(os.Args[2:]))
+		return
+	}
+
 	flag.Parse()
 
 	if srcFile == nil || len(*srcFile) == 0 {
 		log.Fatal("input file not provided")
 	}
 
+	// flags set explicitly take precedence over the config file
+	set := map[string]bool{}
+	flag.Visit(func(f *flag.Flag) { set[f.Name] = true })
+
+	cfg, cfgFile, err := internal.FindConfig(filepath.Dir(*srcFile))
+	Must(err)
+
+	if cfgFile != "" {
+		log.Printf("config: %s", cfgFile)
+	}
+
+	if !set["out"] {
+		*dstFile = cfg.OutFile(*srcFile)
+	}
+
 	if dstFile == nil || len(*dstFile) == 0 {
-		log.Fatal("input file not provided")
+		log.Fatal("output file not provided")
+	}
+
+	if !set["outpkg"] {
+		*dstPkg = cfg.OutPkg
 	}
 
+	if !set["debug"] {
+		*debug = cfg.Debug != nil && *cfg.Debug
+	}
+
+	pkgSet := token.NewFileSet()
+	var pkgFiles []*ast.File
+	if protoIn == nil || len(*protoIn) == 0 {
+		pkgFiles = Must2(packageFiles(pkgSet, *srcFile))
+		cfg = cfg.WithAliases(Must2(internal.PackageAliases(pkgSet, pkgFiles...)))
+	}
+
+	cfg.Apply()
+
 	if debug != nil && *debug {
 		log.Printf("using slog")
 		internal.UseSlog()
 	}
 
-	log.Printf("destination package: %s", *dstPkg)
+	if msgFile != nil && len(*msgFile) != 0 {
+		Must(internal.UseMessages(*msgFile))
+	}
 
-	set := token.NewFileSet()
-	f := Must2(os.Open(*srcFile))
-	parsed := Must2(parser.ParseFile(set, f.Name(), f, parser.AllErrors))
+	if set["name-tag"] {
+		internal.NameTag = *nameTag
+	}
 
-	structs := Must2(internal.FindStructs(parsed))
+	if set["tag-keys"] && len(*tagKeys) != 0 {
+		internal.TagKeys = strings.Split(*tagKeys, ",")
+	}
+
+	if set["receiver"] {
+		internal.Receivers = Must2(internal.ParseReceiverKind(*receiver))
+	}
 
-	dst := Must2(os.OpenFile(*dstFile, os.O_CREATE|os.O_WRONLY, 0o600))
-	Must(dst.Truncate(0))
+	if set["unexported"] {
+		internal.Unexported = *unexport
+	}
+
+	if set["errors"] {
+		internal.Errors = internal.ErrorStyle(*errStyle)
+		if internal.Errors != internal.FailFast && internal.Errors != internal.CollectAll {
+			log.Fatalf("unknown errors: %q", *errStyle)
+		}
+	}
 
-	methods := make([]ast.Decl, 0)
+	log.Printf("destination package: %s", *dstPkg)
+
+	var structs []internal.Struct
+	if protoIn != nil && len(*protoIn) != 0 {
+		structs = Must2(importProto(*protoIn))
+	} else {
+		structs = internal.DetectReceivers(Must2(internal.FindStructsInFile(token.NewFileSet(), *srcFile)), pkgFiles...)
+	}
+
+	// the validations are generated first, so the exporters do not write the files for the rules rejected by the generator
 	log.Printf("validations: %#v", structs)
+	generated := Must2(internal.GenerateFile(structs, *dstPkg))
+
+	if catalog != nil && len(*catalog) != 0 {
+		content := Must2(json.MarshalIndent(internal.BuildCatalog(structs), "", "\t"))
+		Must(os.WriteFile(*catalog, append(content, '\n'), 0o600))
+	}
+
+	if schemas != nil && len(*schemas) != 0 {
+		Must(writeSchemas(*schemas, structs))
+	}
+
+	if openAPI != nil && len(*openAPI) != 0 {
+		Must(mergeOpenAPI(*openAPI, structs))
+	}
+
+	if zodFile != nil && len(*zodFile) != 0 {
+		Must(writeZod(*zodFile, structs))
+	}
+
+	if protoOut != nil && len(*protoOut) != 0 {
+		Must(writeProto(*protoOut, structs))
+	}
+
+	if sqlFile != nil && len(*sqlFile) != 0 {
+		Must(writeSQL(*sqlFile, structs))
+	}
+
+	if genTests != nil && len(*genTests) != 0 {
+		content := Must2(internal.GenerateTests(structs, *dstPkg))
+		Must(os.WriteFile(*genTests, content, 0o600))
+	}
 
-	var imports []string
-	for _, str := range structs {
-		var stmts []ast.Stmt
+	if genFuzz != nil && len(*genFuzz) != 0 {
+		content := Must2(internal.GenerateFuzz(structs, *dstPkg))
+		Must(os.WriteFile(*genFuzz, content, 0o600))
+	}
 
-		for _, field := range str.Fields {
-			for validation := range field.Validations {
-				gen := internal.GeneratorFor(validation)
-				if gen == nil {
-					panic(fmt.Sprintf("validator not found for struct: %q, field: %q, validation: %q", str.Name, field.Name, validation))
-				}
+	Must(os.WriteFile(*dstFile, generated, 0o600))
+}
 
-				generated, err := gen.Generate(validation, str, field)
-				if err != nil {
-					panic(err)
-				}
+// packageFiles parses the files of the package of the input file, in its directory, e.g. for the aliases
+// and the receivers of the methods declared in the package. Like go build, it skips the files excluded
+// by the build constraints and the test files, unless the input is a test file.
+func packageFiles(fset *token.FileSet, in string) ([]*ast.File, error) {
+	input, err := parser.ParseFile(fset, in, nil, parser.PackageClauseOnly)
+	if err != nil {
+		return nil, fmt.Errorf("parsing input file: %w", err)
+	}
 
-				stmts = append(stmts, generated.Stmts...)
-				imports = append(imports, generated.Imports...)
+	dir := filepath.Dir(in)
+	paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, fmt.Errorf("listing files of the package: %w", err)
+	}
+
+	// the test files belong to the package only when the input is one of them
+	tests := strings.HasSuffix(in, "_test.go")
+	var files []*ast.File
+	for _, path := range paths {
+		name := filepath.Base(path)
+		isInput := name == filepath.Base(in)
+		if !isInput && strings.HasSuffix(name, "_test.go") != tests {
+			continue
+		}
+
+		// the files excluded by the build constraints, e.g. //go:build ignore, are not the part of the package
+		if match, err := build.Default.MatchFile(dir, name); !isInput && (err != nil || !match) {
+			continue
+		}
+
+		f, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+		if err != nil {
+			if isInput {
+				return nil, fmt.Errorf("parsing input file: %w", err)
 			}
+
+			log.Printf("skipping file of the package: %v", err)
+			continue
 		}
 
-		// TODO: check if there are multiple structs and join them into single error (like errors.Join).
-		// Make sure the solution is compatible with go-playground/validator type validator.ValidationErrors,
-		// so it can be used with the translator?
-
-		stmts = append(stmts, internal.NoError())
-
-		if len(stmts) != 0 {
-			methods = append(methods, &ast.FuncDecl{
-				Doc: &ast.CommentGroup{
-					List: []*ast.Comment{
-						{Text: "// Validate implements Validator."},
-					},
-				},
-				Recv: &ast.FieldList{List: []*ast.Field{{Type: &ast.Ident{Name: internal.Receiver(str)}}}},
-				Name: &ast.Ident{Name: "Validate"},
-				Type: &ast.FuncType{Results: &ast.FieldList{List: []*ast.Field{{Type: &ast.Ident{Name: "error"}}}}},
-				Body: &ast.BlockStmt{List: stmts},
-			})
+		if f.Name.Name == input.Name.Name {
+			files = append(files, f)
 		}
 	}
 
-	file := &ast.File{
-		Name:  &ast.Ident{Name: *dstPkg},
-		Decls: methods,
-		Doc: &ast.CommentGroup{
-			List: []*ast.Comment{
-				// FIXME this comment is
-				{Text: "// File generated automatically by validator. DO NOT EDIT."},
-			},
-		},
+	return files, nil
+}
+
+// writeSchemas writes the JSON Schema of every struct to the file named with internal.SchemaRef in the dir.
+func writeSchemas(dir string, structs []internal.Struct) error {
+	schemas, err := internal.JSONSchemas(structs)
+	if err != nil {
+		return fmt.Errorf("building JSON Schemas: %w", err)
+	}
+
+	if err = os.MkdirAll(dir, 0o750); err != nil {
+		return fmt.Errorf("creating directory: %w", err)
 	}
 
-	dstFs := token.NewFileSet()
-	astutil.AddImport(dstFs, file, "errors")
+	for name, schema := range schemas {
+		content, err := json.MarshalIndent(schema, "", "\t")
+		if err != nil {
+			return fmt.Errorf("encoding JSON Schema: %q, %w", name, err)
+		}
 
-	slices.Sort(imports)
-	imports = slices.Compact(imports)
-	for _, imp := range imports {
-		astutil.AddImport(dstFs, file, imp)
+		if err = os.WriteFile(filepath.Join(dir, internal.SchemaRef(name)), append(content, '\n'), 0o600); err != nil {
+			return fmt.Errorf("writing JSON Schema: %q, %w", name, err)
+		}
 	}
 
-	err := format.Node(dst, dstFs, file)
+	return nil
+}
+
+// mergeOpenAPI merges the schemas of the structs into the OpenAPI document, creating it if it does not exist.
+func mergeOpenAPI(path string, structs []internal.Struct) error {
+	schemas, err := internal.OpenAPISchemas(structs)
 	if err != nil {
-		panic(err)
+		return fmt.Errorf("building OpenAPI schemas: %w", err)
+	}
+
+	doc, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("reading OpenAPI document: %w", err)
 	}
 
-	// FIXME this is a hack to format the output file, which should (?) be guaranteed to be properly formatted by format.Node
-	err = exec.Command("go", "fmt", dst.Name()).Run()
+	merged, err := internal.MergeOpenAPI(doc, *dstPkg, schemas)
 	if err != nil {
-		panic(err)
+		return err
 	}
+
+	return os.WriteFile(path, merged, 0o600)
+}
+
+// writeZod writes the Zod schemas of the structs to the file, logging the rules without the equivalent in Zod.
+func writeZod(path string, structs []internal.Struct) error {
+	content, warnings, err := internal.ZodSchemas(structs)
+	if err != nil {
+		return fmt.Errorf("building Zod schemas: %w", err)
+	}
+
+	for _, w := range warnings {
+		log.Printf("warning: %s", w)
+	}
+
+	return os.WriteFile(path, content, 0o600)
+}
+
+// writeProto writes the messages of the structs to the file, logging the rules without the equivalent in protovalidate.
+func writeProto(path string, structs []internal.Struct) error {
+	goPkg := *protoPkg
+	if goPkg == "" {
+		var err error
+		if goPkg, err = importPath(filepath.Dir(path)); err != nil {
+			return fmt.Errorf("option go_package of the proto: %w, set it with -proto-go-package", err)
+		}
+	}
+
+	content, warnings, err := internal.ProtoFile(structs, *dstPkg, goPkg)
+	if err != nil {
+		return fmt.Errorf("building proto: %w", err)
+	}
+
+	for _, w := range warnings {
+		log.Printf("warning: %s", w)
+	}
+
+	return os.WriteFile(path, content, 0o600)
+}
+
+// writeSQL writes the migration of the structs to the file, logging the rules without the SQL equivalent.
+func writeSQL(path string, structs []internal.Struct) error {
+	content, warnings, err := internal.SQLMigration(structs)
+	if err != nil {
+		return fmt.Errorf("building migration: %w", err)
+	}
+
+	for _, w := range warnings {
+		log.Printf("warning: %s", w)
+	}
+
+	return os.WriteFile(path, content, 0o600)
+}
+
+// importPath returns the import path of the package in the directory, from the module path of the nearest go.mod.
+func importPath(dir string) (string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	for current := abs; ; current = filepath.Dir(current) {
+		content, err := os.ReadFile(filepath.Join(current, "go.mod"))
+		if err == nil {
+			rel, err := filepath.Rel(current, abs)
+			if err != nil {
+				return "", err
+			}
+
+			return path.Join(modfile.ModulePath(content), filepath.ToSlash(rel)), nil
+		}
+
+		if !errors.Is(err, fs.ErrNotExist) {
+			return "", fmt.Errorf("reading go.mod: %w", err)
+		}
+
+		if filepath.Dir(current) == current {
+			return "", fmt.Errorf("go.mod not found in %s or its parents", abs)
+		}
+	}
+}
+
+// importProto returns the structs of the messages of the .proto file, logging the constraints without the equivalent rule.
+func importProto(path string) ([]internal.Struct, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening proto: %w", err)
+	}
+	defer f.Close()
+
+	structs, warnings, err := internal.ImportProto(f, path)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, w := range warnings {
+		log.Printf("warning: %s", w)
+	}
+
+	return structs, nil
 }
 
 func Must(err error) {
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+
+	"github.com/paluszkiewiczB/validator/migrate"
+)
+
+// runMigrate runs the subcommand 'migrate', which rewrites the calls of go-playground/validator
+// in the packages to the generated Validate methods, see migrate.Analyzer.
+func runMigrate(args []string) error {
+	flags := flag.NewFlagSet("migrate", flag.ExitOnError)
+	write := flags.Bool("w", false, "write the rewritten files, instead of only listing the call sites")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "usage: validator migrate [-w] [packages]\n")
+		flags.PrintDefaults()
+	}
+	Must(flags.Parse(args))
+
+	patterns := flags.Args()
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+
+	pkgs, err := packages.Load(&packages.Config{Mode: packages.LoadAllSyntax, Tests: true}, patterns...)
+	if err != nil {
+		return fmt.Errorf("loading packages: %w", err)
+	}
+
+	if packages.PrintErrors(pkgs) > 0 {
+		return fmt.Errorf("packages contain errors")
+	}
+
+	graph, err := checker.Analyze([]*analysis.Analyzer{migrate.Analyzer}, pkgs, nil)
+	if err != nil {
+		return fmt.Errorf("analyzing packages: %w", err)
+	}
+
+	// the files of the packages are analyzed again in their test variants
+	reported := map[string]bool{}
+	edits := map[string][]textEdit{}
+	for act := range graph.All() {
+		if !act.IsRoot {
+			continue
+		}
+
+		if act.Err != nil {
+			return fmt.Errorf("analyzing package %s: %w", act.Package, act.Err)
+		}
+
+		for _, d := range act.Diagnostics {
+			pos := act.Package.Fset.Position(d.Pos)
+			line := fmt.Sprintf("%s: %s", pos, d.Message)
+			if reported[line] {
+				continue
+			}
+
+			reported[line] = true
+			log.Print(line)
+			for _, fix := range d.SuggestedFixes {
+				for _, edit := range fix.TextEdits {
+					file := act.Package.Fset.File(edit.Pos)
+					edits[file.Name()] = append(edits[file.Name()], textEdit{start: file.Offset(edit.Pos), end: file.Offset(edit.End), text: edit.NewText})
+				}
+			}
+		}
+	}
+
+	if !*write {
+		return nil
+	}
+
+	for name, fileEdits := range edits {
+		if err := applyEdits(name, fileEdits); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// textEdit replaces the bytes of the file between the offsets with the text.
+type textEdit struct {
+	start, end int
+	text       []byte
+}
+
+// applyEdits applies the edits to the file and formats it.
+func applyEdits(name string, edits []textEdit) error {
+	content, err := os.ReadFile(name)
+	if err != nil {
+		return fmt.Errorf("reading file: %w", err)
+	}
+
+	// edits are applied from the end, so the offsets of the following ones do not change
+	slices.SortFunc(edits, func(a, b textEdit) int { return b.start - a.start })
+	edits = slices.CompactFunc(edits, func(a, b textEdit) bool { return a.start == b.start })
+	for _, e := range edits {
+		content = slices.Concat(content[:e.start], e.text, content[e.end:])
+	}
+
+	formatted, err := format.Source(content)
+	if err != nil {
+		return fmt.Errorf("formatting file: %q, %w", name, err)
+	}
+
+	return os.WriteFile(name, formatted, 0o600)
+}
@@ -0,0 +1,215 @@
+// Package migrate provides the analysis.Analyzer rewriting the calls of Struct of go-playground/validator
+// to the Validate methods generated by the validator.
+package migrate
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// Analyzer reports the calls of Struct and StructCtx of go-playground/validator, e.g. 'validate.Struct(req)',
+// with the suggested fix replacing them with 'req.Validate()'. The error handling around the call is preserved.
+// The calls, which can not be converted, are reported without the fix:
+//   - the type of the argument has no method 'Validate() error', e.g. it was not generated yet,
+//   - the validator has custom validations, aliases or types registered in the package,
+//   - the validator is not the variable or the field of the package, e.g. 'newValidator().Struct(req)', or it is
+//     registered on such validator, so the registrations can not be checked,
+//   - the methods with no generated equivalent, like StructPartial, StructExcept and StructFiltered.
+//
+// The uses of validator.ValidationErrors are reported too, the generated code returns *validation.FieldError.
+var Analyzer = &analysis.Analyzer{
+	Name: "validatemigrate",
+	Doc:  "rewrites calls of Struct of go-playground/validator to the generated Validate methods",
+	URL:  "https://github.com/paluszkiewiczB/validator",
+	Run:  run,
+}
+
+// Methods of validator.Validate, which are converted, and which have no generated equivalent.
+var (
+	converted   = map[string]bool{"Struct": true, "StructCtx": true}
+	unsupported = map[string]bool{
+		"StructPartial": true, "StructPartialCtx": true,
+		"StructExcept": true, "StructExceptCtx": true,
+		"StructFiltered": true, "StructFilteredCtx": true,
+	}
+	registrations = map[string]bool{
+		"RegisterValidation": true, "RegisterValidationCtx": true,
+		"RegisterStructValidation": true, "RegisterStructValidationCtx": true, "RegisterStructValidationMapRules": true,
+		"RegisterAlias": true, "RegisterCustomTypeFunc": true,
+	}
+)
+
+func run(pass *analysis.Pass) (any, error) {
+	registered := map[types.Object]token.Pos{}
+	// anonymous is the position of the first registration on the validator, which is not the variable or the field
+	anonymous := token.NoPos
+	var calls []*ast.CallExpr
+	for _, file := range pass.Files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+
+			name, recv, ok := validatorMethod(pass, call)
+			switch {
+			case !ok:
+			case registrations[name] && recv == nil:
+				if !anonymous.IsValid() {
+					anonymous = call.Pos()
+				}
+			case registrations[name]:
+				if _, seen := registered[recv]; !seen {
+					registered[recv] = call.Pos()
+				}
+			case converted[name], unsupported[name]:
+				calls = append(calls, call)
+			}
+
+			return true
+		})
+	}
+
+	converts := 0
+	for _, call := range calls {
+		name, recv, _ := validatorMethod(pass, call)
+		if unsupported[name] {
+			pass.Reportf(call.Pos(), "call of %s not converted: the generated code has no equivalent", name)
+			continue
+		}
+
+		if recv == nil {
+			pass.Reportf(call.Pos(), "call of %s not converted: the validator is not a variable or a field, its registrations can not be checked", name)
+			continue
+		}
+
+		if recv.Pkg() != pass.Pkg {
+			pass.Reportf(call.Pos(), "call of %s not converted: validator %s is declared in package %s, its registrations can not be checked", name, recv.Name(), recv.Pkg().Path())
+			continue
+		}
+
+		if anonymous.IsValid() {
+			pass.Reportf(call.Pos(), "call of %s not converted: custom validations are registered at %s on the validator, which is not a variable or a field", name, pass.Fset.Position(anonymous))
+			continue
+		}
+
+		if at, ok := registered[recv]; ok {
+			pass.Reportf(call.Pos(), "call of %s not converted: validator %s has custom validations registered at %s", name, recv.Name(), pass.Fset.Position(at))
+			continue
+		}
+
+		arg := call.Args[len(call.Args)-1]
+		if !hasValidate(pass.TypesInfo.TypeOf(arg)) {
+			pass.Reportf(call.Pos(), "call of %s not converted: type %s has no method Validate() error, generate it first", name, pass.TypesInfo.TypeOf(arg))
+			continue
+		}
+
+		replacement := receiver(pass.Fset, arg) + ".Validate()"
+		converts++
+		pass.Report(analysis.Diagnostic{
+			Pos:     call.Pos(),
+			End:     call.End(),
+			Message: fmt.Sprintf("call of %s can be replaced with %s", name, replacement),
+			SuggestedFixes: []analysis.SuggestedFix{{
+				Message:   "Replace with " + replacement,
+				TextEdits: []analysis.TextEdit{{Pos: call.Pos(), End: call.End(), NewText: []byte(replacement)}},
+			}},
+		})
+	}
+
+	if converts == 0 {
+		return nil, nil
+	}
+
+	for ident, obj := range pass.TypesInfo.Uses {
+		if isValidatorPkg(obj.Pkg()) && obj.Name() == "ValidationErrors" {
+			pass.Reportf(ident.Pos(), "validator.ValidationErrors is not returned by the generated Validate, handle *validation.FieldError instead")
+		}
+	}
+
+	return nil, nil
+}
+
+// validatorMethod returns the name of the method of validator.Validate called by the call and the object
+// of the receiver, when the receiver is the variable or the field.
+func validatorMethod(pass *analysis.Pass, call *ast.CallExpr) (string, types.Object, bool) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return "", nil, false
+	}
+
+	fn, ok := pass.TypesInfo.Uses[sel.Sel].(*types.Func)
+	if !ok || !isValidatorPkg(fn.Pkg()) {
+		return "", nil, false
+	}
+
+	recv := fn.Type().(*types.Signature).Recv()
+	if recv == nil || len(call.Args) == 0 {
+		return "", nil, false
+	}
+
+	var obj types.Object
+	switch x := ast.Unparen(sel.X).(type) {
+	case *ast.Ident:
+		obj = pass.TypesInfo.Uses[x]
+	case *ast.SelectorExpr:
+		obj = pass.TypesInfo.Uses[x.Sel]
+	}
+
+	return fn.Name(), obj, true
+}
+
+// isValidatorPkg returns true for the major versions of go-playground/validator, both from github.com and gopkg.in.
+func isValidatorPkg(pkg *types.Package) bool {
+	if pkg == nil {
+		return false
+	}
+
+	path := pkg.Path()
+	return strings.HasPrefix(path, "github.com/go-playground/validator") || strings.HasPrefix(path, "gopkg.in/go-playground/validator.")
+}
+
+// hasValidate returns true, when the method set of the type has the method 'Validate() error'.
+func hasValidate(t types.Type) bool {
+	if t == nil {
+		return false
+	}
+
+	sel := types.NewMethodSet(t).Lookup(nil, "Validate")
+	if sel == nil {
+		return false
+	}
+
+	sig := sel.Type().(*types.Signature)
+	return sig.Params().Len() == 0 && sig.Results().Len() == 1 && types.Identical(sig.Results().At(0).Type(), types.Universe.Lookup("error").Type())
+}
+
+// receiver returns the source of the argument as the receiver of the method call.
+// Address of the addressable operand is dropped, e.g. '&req' becomes 'req'.
+func receiver(fset *token.FileSet, arg ast.Expr) string {
+	if u, ok := arg.(*ast.UnaryExpr); ok && u.Op == token.AND {
+		switch u.X.(type) {
+		case *ast.Ident, *ast.SelectorExpr, *ast.IndexExpr:
+			arg = u.X
+		}
+	}
+
+	src := &bytes.Buffer{}
+	if err := format.Node(src, fset, arg); err != nil {
+		panic(err)
+	}
+
+	switch arg.(type) {
+	case *ast.Ident, *ast.SelectorExpr, *ast.IndexExpr, *ast.CallExpr, *ast.CompositeLit, *ast.ParenExpr:
+		return src.String()
+	}
+
+	return "(" + src.String() + ")"
+}
@@ -0,0 +1,13 @@
+package migrate_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/paluszkiewiczB/validator/migrate"
+)
+
+func Test_Analyzer(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), migrate.Analyzer, "example", "anonymous")
+}
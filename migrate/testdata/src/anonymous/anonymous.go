@@ -0,0 +1,24 @@
+package anonymous
+
+import "github.com/go-playground/validator/v10"
+
+type Request struct {
+	Name string `validate:"required,even"`
+}
+
+// Validate is generated by the validator.
+func (r Request) Validate() error { return nil }
+
+var validate = validator.New()
+
+func instance() *validator.Validate {
+	return validate
+}
+
+func init() {
+	_ = instance().RegisterValidation("even", func(fl validator.FieldLevel) bool { return true })
+}
+
+func notConverted(req Request) error {
+	return validate.Struct(req) // want `call of Struct not converted: custom validations are registered at .*anonymous.go:19:6 on the validator, which is not a variable or a field`
+}
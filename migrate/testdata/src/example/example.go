@@ -0,0 +1,71 @@
+package example
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-playground/validator/v10"
+
+	"shared"
+)
+
+type Request struct {
+	Name string `validate:"required"`
+}
+
+// Validate is generated by the validator.
+func (r Request) Validate() error { return nil }
+
+type Pointer struct {
+	Name string `validate:"required"`
+}
+
+// Validate is generated by the validator.
+func (p *Pointer) Validate() error { return nil }
+
+type Legacy struct {
+	Name string `validate:"required"`
+}
+
+var validate = validator.New()
+
+type handler struct {
+	validate *validator.Validate
+}
+
+func converted(ctx context.Context, h handler, req Request, reqs []Request) error {
+	if err := validate.Struct(req); err != nil { // want `call of Struct can be replaced with req.Validate\(\)`
+		return err
+	}
+
+	err := h.validate.StructCtx(ctx, &reqs[0]) // want `call of StructCtx can be replaced with reqs\[0\].Validate\(\)`
+	if err != nil {
+		return err
+	}
+
+	return validate.Struct(&Pointer{}) // want `call of Struct can be replaced with \(&Pointer\{\}\).Validate\(\)`
+}
+
+func handled(err error) bool {
+	var errs validator.ValidationErrors // want `validator.ValidationErrors is not returned by the generated Validate, handle \*validation.FieldError instead`
+	return errors.As(err, &errs)
+}
+
+func notConverted(legacy Legacy, p Pointer) {
+	_ = validate.Struct(legacy)                // want `call of Struct not converted: type example.Legacy has no method Validate\(\) error, generate it first`
+	_ = validate.Struct(p)                     // want `call of Struct not converted: type example.Pointer has no method Validate\(\) error, generate it first`
+	_ = validate.StructPartial(legacy, "Name") // want `call of StructPartial not converted: the generated code has no equivalent`
+
+	custom := validator.New()
+	_ = custom.RegisterValidation("even", func(fl validator.FieldLevel) bool { return true })
+	_ = custom.Struct(Request{}) // want `call of Struct not converted: validator custom has custom validations registered at .*example.go:60:6`
+}
+
+func newValidator() *validator.Validate {
+	return validate
+}
+
+func unknownReceivers() {
+	_ = newValidator().Struct(Request{})  // want `call of Struct not converted: the validator is not a variable or a field, its registrations can not be checked`
+	_ = shared.Validate.Struct(Request{}) // want `call of Struct not converted: validator Validate is declared in package shared, its registrations can not be checked`
+}
@@ -0,0 +1,10 @@
+package shared
+
+import "github.com/go-playground/validator/v10"
+
+// Validate has the custom validations, which the packages using it do not see.
+var Validate = validator.New()
+
+func init() {
+	_ = Validate.RegisterValidation("even", func(fl validator.FieldLevel) bool { return true })
+}
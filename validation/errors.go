@@ -0,0 +1,86 @@
+// Package validation contains the types used by the generated code at runtime.
+package validation
+
+import (
+	"errors"
+	"strings"
+)
+
+// FieldError is the error returned by the generated Validate methods when the field fails the validation.
+// Fields are set at generation time, so translating the error does not require reflection, see Translate.
+type FieldError struct {
+	// Struct is the name of the validated struct, e.g. 'User'.
+	Struct string
+	// Field is the name of the field which failed the validation, e.g. 'password'.
+	// It is the name from the tag chosen at generation time (e.g. json) or the StructField, when the tag is not present.
+	Field string
+	// StructField is the name of the field in Go, e.g. 'Password'.
+	StructField string
+	// Tag is the ID of the validation rule, e.g. 'required' or 'eqfield'.
+	// For the rule expanded from the alias it is the name of the alias, e.g. 'iscolor'.
+	Tag string
+	// ActualTag is the Tag with the aliases expanded, e.g. 'hexcolor|rgb|rgba' for 'iscolor'.
+	// It is the same as the Tag, when the rule is not an alias.
+	ActualTag string
+	// Param is the parameter of the validation rule, e.g. 'Repeated' for 'eqfield=Repeated'.
+	Param string
+	// Value is the value of the field.
+	Value any
+	// Message is the error message resolved from the template at generation time.
+	Message string
+}
+
+// Error implements error.
+func (e *FieldError) Error() string {
+	return e.Message
+}
+
+// Namespace returns the Field qualified with the struct name, e.g. 'User.password'.
+func (e *FieldError) Namespace() string {
+	return e.Struct + "." + e.Field
+}
+
+// StructNamespace returns the StructField qualified with the struct name, e.g. 'User.Password'.
+func (e *FieldError) StructNamespace() string {
+	return e.Struct + "." + e.StructField
+}
+
+// FieldErrors are the errors of all the invalid fields, returned by the Validate methods generated with
+// the collect-all error style. Every field is reported once, with its first violated rule.
+type FieldErrors []*FieldError
+
+// Error implements error, the messages of the errors are joined with the new line.
+func (e FieldErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, fe := range e {
+		msgs = append(msgs, fe.Error())
+	}
+
+	return strings.Join(msgs, "\n")
+}
+
+// AsFieldErrors returns the FieldErrors of the err, which is either *FieldError or FieldErrors, e.g. returned by
+// the Validate method of the nested struct. It returns false for other errors.
+func AsFieldErrors(err error) (FieldErrors, bool) {
+	var errs FieldErrors
+	if errors.As(err, &errs) {
+		return errs, true
+	}
+
+	var fe *FieldError
+	if errors.As(err, &fe) {
+		return FieldErrors{fe}, true
+	}
+
+	return nil, false
+}
+
+// Unwrap returns the errors, so errors.As finds the first FieldError.
+func (e FieldErrors) Unwrap() []error {
+	errs := make([]error, 0, len(e))
+	for _, fe := range e {
+		errs = append(errs, fe)
+	}
+
+	return errs
+}
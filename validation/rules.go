@@ -0,0 +1,38 @@
+package validation
+
+import (
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// IsEmail returns true, when the string is the email address without the display name, e.g. 'a@b.co'.
+// The generated code of the rule email calls it.
+func IsEmail(s string) bool {
+	addr, err := mail.ParseAddress(s)
+	return err == nil && addr.Address == s
+}
+
+// IsURL returns true, when the string is the absolute URL with the host, e.g. 'https://example.com/a?b#c',
+// or with the opaque part, e.g. 'mailto:a@b.co'. The generated code of the rule url calls it.
+func IsURL(s string) bool {
+	s, _, _ = strings.Cut(s, "#")
+	u, err := url.ParseRequestURI(s)
+	return err == nil && u.Scheme != "" && (u.Host != "" || u.Opaque != "")
+}
+
+// patterns are the compiled patterns of MatchString, keyed by the pattern.
+var patterns sync.Map
+
+// MatchString returns true, when the string contains the match of the pattern, which is compiled once.
+// The generated code of the rule regexp calls it, the pattern is checked at generation time.
+func MatchString(pattern, s string) bool {
+	re, ok := patterns.Load(pattern)
+	if !ok {
+		re, _ = patterns.LoadOrStore(pattern, regexp.MustCompile(pattern))
+	}
+
+	return re.(*regexp.Regexp).MatchString(s)
+}
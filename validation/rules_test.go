@@ -0,0 +1,44 @@
+package validation_test
+
+import (
+	"testing"
+
+	"github.com/paluszkiewiczB/validator/validation"
+)
+
+func Test_IsEmail(t *testing.T) {
+	cases := map[string]bool{
+		"a@b.co":            true,
+		"a.b+c@example.com": true,
+		"":                  false,
+		"a":                 false,
+		"a@":                false,
+		"Ann <a@b.co>":      false,
+		" a@b.co":           false,
+	}
+
+	for s, expected := range cases {
+		if got := validation.IsEmail(s); got != expected {
+			t.Errorf("IsEmail(%q), expected: %t, got: %t", s, expected, got)
+		}
+	}
+}
+
+func Test_IsURL(t *testing.T) {
+	cases := map[string]bool{
+		"https://example.com":         true,
+		"https://example.com/a?b=c#d": true,
+		"mailto:a@b.co":               true,
+		"":                            false,
+		"example.com":                 false,
+		"/a/b":                        false,
+		"https://":                    false,
+		"#fragment":                   false,
+	}
+
+	for s, expected := range cases {
+		if got := validation.IsURL(s); got != expected {
+			t.Errorf("IsURL(%q), expected: %t, got: %t", s, expected, got)
+		}
+	}
+}
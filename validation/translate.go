@@ -0,0 +1,152 @@
+package validation
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"sync"
+)
+
+// Placeholders of the message templates in the Catalog.
+const (
+	PlaceholderField = "{field}"
+	PlaceholderParam = "{param}"
+	PlaceholderValue = "{value}"
+)
+
+// Catalog is the set of message templates of a single locale.
+// Keys are either the Tag of the rule, e.g. 'required', or the rule of the field qualified with the StructNamespace,
+// e.g. 'User.Password.required'. The qualified key takes precedence over the Tag.
+type Catalog map[string]string
+
+// LoadCatalog reads the Catalog from the JSON object of keys to the message templates:
+//
+//	{"required": "field \"{field}\" is required"}
+func LoadCatalog(r io.Reader) (Catalog, error) {
+	c := Catalog{}
+	if err := json.NewDecoder(r).Decode(&c); err != nil {
+		return nil, fmt.Errorf("decoding catalog: %w", err)
+	}
+
+	return c, nil
+}
+
+//go:embed catalogs/*.json
+var defaultCatalogs embed.FS
+
+var (
+	catalogsMu sync.RWMutex
+	catalogs   = mustLoadDefaults()
+)
+
+func mustLoadDefaults() map[string]Catalog {
+	entries, err := defaultCatalogs.ReadDir("catalogs")
+	if err != nil {
+		panic(err)
+	}
+
+	out := make(map[string]Catalog, len(entries))
+	for _, e := range entries {
+		f, err := defaultCatalogs.Open(path.Join("catalogs", e.Name()))
+		if err != nil {
+			panic(err)
+		}
+
+		c, err := LoadCatalog(f)
+		if err != nil {
+			panic(fmt.Errorf("default catalog: %q, %w", e.Name(), err))
+		}
+
+		out[strings.TrimSuffix(e.Name(), ".json")] = c
+	}
+
+	return out
+}
+
+// RegisterCatalog adds the templates of the Catalog to the locale, overriding the existing ones.
+// Locales 'en' and 'pl' are registered by default.
+func RegisterCatalog(locale string, c Catalog) {
+	catalogsMu.Lock()
+	defer catalogsMu.Unlock()
+
+	merged := Catalog{}
+	for k, v := range catalogs[locale] {
+		merged[k] = v
+	}
+
+	for k, v := range c {
+		merged[k] = v
+	}
+
+	catalogs[locale] = merged
+}
+
+// Translate returns the message of the FieldError in the locale, e.g. 'pl' or 'pl-PL'.
+// When the locale is not registered, the base language of the locale is used, e.g. 'pl' for 'pl-PL'.
+// The template is looked up by the rule qualified with the StructNamespace, by the Tag and then by the ActualTag,
+// so the alias of a single rule is translated as the rule. The default catalogs have no templates of the alternatives
+// and the aliases of multiple rules, their keys are declared by the project, see the -catalog flag of the generator.
+// When the error is not a FieldError or the template is not found, it returns the message of the error.
+// FieldErrors are translated one by one and joined with the new line.
+func Translate(err error, locale string) string {
+	if err == nil {
+		return ""
+	}
+
+	var errs FieldErrors
+	if errors.As(err, &errs) {
+		msgs := make([]string, 0, len(errs))
+		for _, fe := range errs {
+			msgs = append(msgs, Translate(fe, locale))
+		}
+
+		return strings.Join(msgs, "\n")
+	}
+
+	var fe *FieldError
+	if !errors.As(err, &fe) {
+		return err.Error()
+	}
+
+	template, ok := lookup(locale, fe)
+	if !ok {
+		return err.Error()
+	}
+
+	return strings.NewReplacer(
+		PlaceholderField, fe.Field,
+		PlaceholderParam, fe.Param,
+		PlaceholderValue, fmt.Sprint(fe.Value),
+	).Replace(template)
+}
+
+func lookup(locale string, fe *FieldError) (string, bool) {
+	catalogsMu.RLock()
+	defer catalogsMu.RUnlock()
+
+	c, ok := catalogs[locale]
+	if !ok {
+		base, _, _ := strings.Cut(strings.ReplaceAll(locale, "_", "-"), "-")
+		c, ok = catalogs[base]
+	}
+
+	if !ok {
+		return "", false
+	}
+
+	if t, ok := c[fe.StructNamespace()+"."+fe.Tag]; ok {
+		return t, true
+	}
+
+	if t, ok := c[fe.Tag]; ok {
+		return t, true
+	}
+
+	// the alias of a single rule, e.g. 'short' of 'max=3', is translated as the rule
+	t, ok := c[fe.ActualTag]
+	return t, ok
+}
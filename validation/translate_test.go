@@ -0,0 +1,134 @@
+package validation_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/paluszkiewiczB/validator/internal"
+	"github.com/paluszkiewiczB/validator/validation"
+)
+
+func Test_Translate(t *testing.T) {
+	err := fmt.Errorf("wrapped: %w", &validation.FieldError{
+		Struct: "User", Field: "Name", StructField: "Name", Tag: "required", Value: "", Message: "field \"Name\" is required",
+	})
+
+	cases := map[string]string{
+		"en":    "field \"Name\" is required",
+		"pl":    "pole \"Name\" jest wymagane",
+		"pl-PL": "pole \"Name\" jest wymagane",
+		"pl_PL": "pole \"Name\" jest wymagane",
+		"de":    "wrapped: field \"Name\" is required",
+	}
+
+	for locale, expected := range cases {
+		t.Run(locale, func(t *testing.T) {
+			if got := validation.Translate(err, locale); got != expected {
+				t.Errorf("expected %q, got %q", expected, got)
+			}
+		})
+	}
+}
+
+func Test_Translate_FieldErrors(t *testing.T) {
+	err := validation.FieldErrors{
+		{Struct: "User", Field: "Name", StructField: "Name", Tag: "required", Message: "field \"Name\" is required"},
+		{Struct: "User", Field: "Age", StructField: "Age", Tag: "gte", Param: "Min", Message: "field \"Age\" must be greater than or equal to \"Min\""},
+	}
+
+	if got, expected := validation.Translate(err, "pl"), "pole \"Name\" jest wymagane\n"+validation.Translate(err[1], "pl"); got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+
+	var fe *validation.FieldError
+	if !errors.As(err, &fe) || fe != err[0] {
+		t.Errorf("expected the first FieldError, got %v", fe)
+	}
+}
+
+func Test_Translate_NotFieldError(t *testing.T) {
+	if got := validation.Translate(errors.New("boom"), "pl"); got != "boom" {
+		t.Errorf("expected message of the error, got %q", got)
+	}
+
+	if got := validation.Translate(nil, "pl"); got != "" {
+		t.Errorf("expected empty message, got %q", got)
+	}
+}
+
+func Test_RegisterCatalog(t *testing.T) {
+	c, err := validation.LoadCatalog(strings.NewReader(`{
+		"gte": "{field} muss größer oder gleich {param} sein, ist {value}",
+		"User.Age.gte": "Du bist zu jung"
+	}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	validation.RegisterCatalog("de", c)
+
+	cases := map[string]struct {
+		err      *validation.FieldError
+		expected string
+	}{
+		"tag":       {err: &validation.FieldError{Struct: "Item", Field: "count", StructField: "Count", Tag: "gte", Param: "Min", Value: 3}, expected: "count muss größer oder gleich Min sein, ist 3"},
+		"namespace": {err: &validation.FieldError{Struct: "User", Field: "age", StructField: "Age", Tag: "gte", Param: "Min", Value: 3}, expected: "Du bist zu jung"},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := validation.Translate(c.err, "de-DE"); got != c.expected {
+				t.Errorf("expected %q, got %q", c.expected, got)
+			}
+		})
+	}
+}
+
+func Test_DefaultCatalogs_CoverBuiltInRules(t *testing.T) {
+	for _, locale := range []string{"en", "pl"} {
+		f, err := os.Open(filepath.Join("catalogs", locale+".json"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		c, err := validation.LoadCatalog(f)
+		_ = f.Close()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		for rule := range internal.DefaultMessages {
+			if _, ok := c[rule]; !ok {
+				t.Errorf("catalog %q misses rule %q", locale, rule)
+			}
+		}
+	}
+}
+
+func Test_Translate_Aliases(t *testing.T) {
+	cases := map[string]struct {
+		err      *validation.FieldError
+		expected string
+	}{
+		"alias of rule": {
+			err:      &validation.FieldError{Struct: "User", Field: "Code", StructField: "Code", Tag: "short", ActualTag: "max", Param: "3", Message: "field \"Code\" must be at most 3"},
+			expected: "pole \"Code\" może mieć co najwyżej 3",
+		},
+		"alternatives": {
+			err:      &validation.FieldError{Struct: "User", Field: "Code", StructField: "Code", Tag: "len|max", ActualTag: "len|max", Message: "field \"Code\" must have length 0 or field \"Code\" must be at most 3"},
+			expected: "field \"Code\" must have length 0 or field \"Code\" must be at most 3",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := validation.Translate(c.err, "pl"); got != c.expected {
+				t.Errorf("expected %q, got %q", c.expected, got)
+			}
+		})
+	}
+}